@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net/http"
+
+	"grade_calculator/data"
+	"grade_calculator/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CreateStudent(c *gin.Context) {
+	var input models.StudentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	student, courseErrors := data.CreateStudent(input)
+	if len(courseErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid courses", "courses": courseErrors})
+		return
+	}
+	c.JSON(http.StatusCreated, student)
+}
+
+func GetAllStudents(c *gin.Context) {
+	c.JSON(http.StatusOK, data.GetAllStudents())
+}
+
+func GetStudent(c *gin.Context) {
+	student, err := data.GetStudentByID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, student)
+}
+
+func DeleteStudent(c *gin.Context) {
+	if err := data.DeleteStudent(c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+package data
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+
+	"grade_calculator/grades"
+	"grade_calculator/models"
+)
+
+type studentRecord struct {
+	name    string
+	courses grades.Grades
+}
+
+var (
+	mu       sync.RWMutex
+	students = make(map[string]studentRecord)
+	nextID   = 1
+	scale    = grades.DefaultScale
+)
+
+// ValidateStudent turns the input into grades, collecting an error for
+// every course that fails validation instead of stopping at the first.
+func ValidateStudent(input models.StudentInput) (grades.Grades, []models.CourseError) {
+	courses := make(grades.Grades)
+	var courseErrors []models.CourseError
+	for _, course := range input.Courses {
+		grade := grades.CourseGrade{Score: *course.Score, Credits: course.Credits}
+		if grade.Credits == 0 {
+			grade.Credits = grades.DefaultCredits
+		}
+		if err := courses.Add(course.Course, grade); err != nil {
+			courseErrors = append(courseErrors, models.CourseError{Course: course.Course, Error: err.Error()})
+		}
+	}
+	return courses, courseErrors
+}
+
+// CreateStudent validates and stores a student. Nothing is stored when any
+// course is invalid.
+func CreateStudent(input models.StudentInput) (models.Student, []models.CourseError) {
+	courses, courseErrors := ValidateStudent(input)
+	if len(courseErrors) > 0 {
+		return models.Student{}, courseErrors
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	id := strconv.Itoa(nextID)
+	nextID++
+	students[id] = studentRecord{name: input.Name, courses: courses}
+	return toStudent(id, students[id]), nil
+}
+
+func GetAllStudents() []models.Student {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	result := make([]models.Student, 0, len(students))
+	for id, record := range students {
+		result = append(result, toStudent(id, record))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, _ := strconv.Atoi(result[i].ID)
+		b, _ := strconv.Atoi(result[j].ID)
+		return a < b
+	})
+	return result
+}
+
+func GetStudentByID(id string) (models.Student, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	record, exists := students[id]
+	if !exists {
+		return models.Student{}, errors.New("student not found")
+	}
+	return toStudent(id, record), nil
+}
+
+func DeleteStudent(id string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := students[id]; !exists {
+		return errors.New("student not found")
+	}
+	delete(students, id)
+	return nil
+}
+
+func toStudent(id string, record studentRecord) models.Student {
+	return models.Student{ID: id, Report: grades.BuildReport(record.name, record.courses, nil, scale, grades.DefaultRounding)}
+}
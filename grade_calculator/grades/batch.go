@@ -0,0 +1,113 @@
+package grades
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// RejectedRow is a batch input row that could not be graded.
+type RejectedRow struct {
+	Line   int
+	Record []string
+	Reason string
+}
+
+// ReadCSV reads student,course,score[,credits] rows in CSV form and groups
+// the valid ones by student. Rows that fail validation are returned with
+// their line numbers instead of aborting the whole batch. A header row is
+// skipped if present. Files whose first line is separated by semicolons are
+// read with ';' as the delimiter, so scores may use a decimal comma.
+func ReadCSV(r io.Reader) (Class, []RejectedRow, error) {
+	reader := newCSVReader(r)
+
+	students := make(Class)
+	var rejected []RejectedRow
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if row, ok := parseErrorRow(err, record); ok {
+				rejected = append(rejected, row)
+				continue
+			}
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		isHeader := first && len(record) >= 3 && strings.EqualFold(strings.TrimSpace(record[2]), "score")
+		first = false
+		if isHeader {
+			continue
+		}
+
+		if len(record) < 3 || len(record) > 4 {
+			rejected = append(rejected, RejectedRow{Line: line, Record: record, Reason: "expected student,course,score[,credits]"})
+			continue
+		}
+		student := strings.TrimSpace(record[0])
+		course := strings.TrimSpace(record[1])
+		if student == "" || course == "" {
+			rejected = append(rejected, RejectedRow{Line: line, Record: record, Reason: "student and course must not be empty"})
+			continue
+		}
+
+		creditsField := ""
+		if len(record) == 4 {
+			creditsField = record[3]
+		}
+		grade, err := ParseGrade(record[2], creditsField)
+		if err != nil {
+			rejected = append(rejected, RejectedRow{Line: line, Record: record, Reason: err.Error()})
+			continue
+		}
+
+		if students[student] == nil {
+			students[student] = make(Grades)
+		}
+		if err := students[student].Add(course, grade); err != nil {
+			rejected = append(rejected, RejectedRow{Line: line, Record: record, Reason: err.Error()})
+		}
+	}
+	return students, rejected, nil
+}
+
+// parseErrorRow turns a malformed CSV line into a rejected row. Other read
+// errors are not about one row and report false.
+func parseErrorRow(err error, record []string) (RejectedRow, bool) {
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) {
+		return RejectedRow{}, false
+	}
+	return RejectedRow{Line: parseErr.Line, Record: record, Reason: parseErr.Err.Error()}, true
+}
+
+// newCSVReader reads CSV with ragged rows allowed and the delimiter picked
+// by detectDelimiter.
+func newCSVReader(r io.Reader) *csv.Reader {
+	buffered := bufio.NewReader(r)
+	reader := csv.NewReader(buffered)
+	reader.Comma = detectDelimiter(buffered)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// detectDelimiter looks at the first line without consuming it and picks
+// ';' when it separates the fields, otherwise ','.
+func detectDelimiter(r *bufio.Reader) rune {
+	head, _ := r.Peek(4096)
+	if i := bytes.IndexByte(head, '\n'); i >= 0 {
+		head = head[:i]
+	}
+	if bytes.Count(head, []byte(";")) > bytes.Count(head, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
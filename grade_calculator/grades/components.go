@@ -0,0 +1,148 @@
+package grades
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Component is one weighted part of a course grade, such as the final exam.
+// Weight is a percentage of the course grade and Score is nil while the
+// component has not been graded.
+type Component struct {
+	Name   string   `json:"name"`
+	Weight float64  `json:"weight"`
+	Score  *float64 `json:"score,omitempty"`
+}
+
+// MissingPolicy decides how components without a score count.
+type MissingPolicy string
+
+const (
+	// MissingZero counts a missing component as a score of 0.
+	MissingZero MissingPolicy = "zero"
+	// MissingReweight leaves missing components out and scales the weights
+	// of the graded ones back up to 100%.
+	MissingReweight MissingPolicy = "reweight"
+)
+
+// weightTolerance absorbs rounding when checking that weights sum to 100%.
+const weightTolerance = 1e-6
+
+// ValidateComponents checks that component names are unique, weights are
+// positive and add up to 100% and that every given score is valid.
+func ValidateComponents(components []Component) error {
+	if len(components) == 0 {
+		return errors.New("a course needs at least one component")
+	}
+	seen := make(map[string]bool)
+	var total float64
+	for _, c := range components {
+		if c.Name == "" {
+			return errors.New("component names must not be empty")
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("component %q is listed twice", c.Name)
+		}
+		seen[c.Name] = true
+		if c.Weight <= 0 {
+			return fmt.Errorf("component %q must have a positive weight", c.Name)
+		}
+		if c.Score != nil {
+			if err := CheckScore(*c.Score); err != nil {
+				return fmt.Errorf("component %q: %w", c.Name, err)
+			}
+		}
+		total += c.Weight
+	}
+	if math.Abs(total-100) > weightTolerance {
+		return fmt.Errorf("component weights add up to %g%%, they must add up to 100%%", total)
+	}
+	return nil
+}
+
+// ComponentScore derives a course score from its components. The score is
+// left unrounded; the rounding policy applies when it is graded.
+func ComponentScore(components []Component, missing MissingPolicy) (float64, error) {
+	if err := ValidateComponents(components); err != nil {
+		return 0, err
+	}
+
+	var total, graded float64
+	for _, c := range components {
+		if c.Score == nil {
+			continue
+		}
+		total += *c.Score * c.Weight
+		graded += c.Weight
+	}
+
+	switch missing {
+	case MissingZero:
+		return total / 100, nil
+	case MissingReweight:
+		if graded == 0 {
+			return 0, errors.New("no component has been graded yet")
+		}
+		return total / graded, nil
+	default:
+		return 0, fmt.Errorf("unknown missing component policy %q", missing)
+	}
+}
+
+// ParseComponent parses a component given as "name:weight=score". The score
+// may be left empty for a component that has not been graded yet.
+func ParseComponent(field string) (Component, error) {
+	nameWeight, scoreText, hasScore := strings.Cut(field, "=")
+	name, weightText, hasWeight := strings.Cut(nameWeight, ":")
+	if !hasScore || !hasWeight {
+		return Component{}, fmt.Errorf("component %q must look like name:weight=score", field)
+	}
+
+	weight, err := ParseScore(strings.TrimSuffix(weightText, "%"))
+	if err != nil {
+		return Component{}, fmt.Errorf("component %q: weight: %w", field, err)
+	}
+	c := Component{Name: strings.TrimSpace(name), Weight: weight}
+	if strings.TrimSpace(scoreText) != "" {
+		score, err := ParseScore(scoreText)
+		if err != nil {
+			return Component{}, fmt.Errorf("component %q: score: %w", field, err)
+		}
+		c.Score = &score
+	}
+	return c, nil
+}
+
+// ParseComponentGrade builds a course grade from "name:weight=score" fields
+// optionally followed by the credit hours.
+func ParseComponentGrade(fields []string, missing MissingPolicy) (CourseGrade, error) {
+	if len(fields) == 0 {
+		return CourseGrade{}, errors.New("a course needs at least one component")
+	}
+	creditsField := ""
+	if last := fields[len(fields)-1]; !strings.Contains(last, ":") {
+		creditsField = last
+		fields = fields[:len(fields)-1]
+	}
+
+	components := make([]Component, 0, len(fields))
+	for _, field := range fields {
+		c, err := ParseComponent(field)
+		if err != nil {
+			return CourseGrade{}, err
+		}
+		components = append(components, c)
+	}
+	score, err := ComponentScore(components, missing)
+	if err != nil {
+		return CourseGrade{}, err
+	}
+
+	credits, err := ParseCredits(creditsField)
+	if err != nil {
+		return CourseGrade{}, err
+	}
+	return CourseGrade{Score: score, Credits: credits, Components: components}, nil
+}
@@ -0,0 +1,55 @@
+package grades
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnknownStudent is returned when a student is not in the gradebook.
+	ErrUnknownStudent = errors.New("student not found")
+	// ErrDuplicateStudent is returned when adding a student that already exists.
+	ErrDuplicateStudent = errors.New("student already exists")
+	// ErrUnknownTerm is returned when a student has no record of a term.
+	ErrUnknownTerm = errors.New("term not found for student")
+	// ErrTooManyAttempts is returned by the prompt once the retry policy is
+	// used up without a valid answer.
+	ErrTooManyAttempts = errors.New("too many invalid attempts")
+)
+
+// ScoreRangeError reports a score outside MinScore..MaxScore.
+type ScoreRangeError struct {
+	Score float64
+}
+
+func (e *ScoreRangeError) Error() string {
+	return fmt.Sprintf("score %g is out of range, score must be between %d and %d", e.Score, MinScore, MaxScore)
+}
+
+// CreditsRangeError reports credit hours that are not positive or exceed
+// MaxCredits.
+type CreditsRangeError struct {
+	Credits float64
+}
+
+func (e *CreditsRangeError) Error() string {
+	return fmt.Sprintf("%g credit hours is out of range, credits must be greater than 0 and at most %d", e.Credits, MaxCredits)
+}
+
+// DuplicateCourseError reports a course that is already recorded.
+type DuplicateCourseError struct {
+	Course string
+}
+
+func (e *DuplicateCourseError) Error() string {
+	return fmt.Sprintf("course %q is already recorded", e.Course)
+}
+
+// UnknownCourseError reports a course that has not been recorded.
+type UnknownCourseError struct {
+	Course string
+}
+
+func (e *UnknownCourseError) Error() string {
+	return fmt.Sprintf("course %q not found", e.Course)
+}
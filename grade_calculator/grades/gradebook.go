@@ -0,0 +1,269 @@
+package grades
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DefaultTerm is the term courses are filed under when no term is given.
+const DefaultTerm = "default"
+
+// RetakePolicy decides which attempt of a retaken course counts.
+type RetakePolicy string
+
+const (
+	// RetakeLatest counts the most recent attempt.
+	RetakeLatest RetakePolicy = "latest"
+	// RetakeBest counts the highest scoring attempt.
+	RetakeBest RetakePolicy = "best"
+	// RetakeFirst counts the first attempt only.
+	RetakeFirst RetakePolicy = "first"
+)
+
+// Term holds the courses a student took in one term.
+type Term struct {
+	Name    string `json:"name"`
+	Courses Grades `json:"courses"`
+}
+
+// StudentRecord holds everything the gradebook knows about one student.
+// Terms are kept in the order they were first recorded. Courses is only
+// read from gradebooks written before terms existed and is moved into the
+// default term on load.
+type StudentRecord struct {
+	Courses Grades  `json:"courses,omitempty"`
+	Terms   []*Term `json:"terms"`
+}
+
+// Term returns the named term, or nil if the student has no such term.
+func (r *StudentRecord) Term(name string) *Term {
+	for _, t := range r.Terms {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Attempt is one try at a course. Term is the index of the term it was in.
+type Attempt struct {
+	Term  int
+	Grade CourseGrade
+}
+
+// CountedAttempts picks, for every course in the first n terms, the attempt
+// that counts under the retake policy.
+func (r *StudentRecord) CountedAttempts(n int, retake RetakePolicy) map[string]Attempt {
+	counted := make(map[string]Attempt)
+	for i, t := range r.Terms[:n] {
+		for course, grade := range t.Courses {
+			current, seen := counted[course]
+			switch {
+			case !seen:
+				counted[course] = Attempt{Term: i, Grade: grade}
+			case retake == RetakeLatest:
+				counted[course] = Attempt{Term: i, Grade: grade}
+			case retake == RetakeBest && grade.Score >= current.Grade.Score:
+				counted[course] = Attempt{Term: i, Grade: grade}
+			}
+		}
+	}
+	return counted
+}
+
+// Grades returns the counted attempt of every course the student took.
+func (r *StudentRecord) Grades(retake RetakePolicy) Grades {
+	grades := make(Grades)
+	for course, a := range r.CountedAttempts(len(r.Terms), retake) {
+		grades[course] = a.Grade
+	}
+	return grades
+}
+
+// Gradebook stores many students' grades term by term and persists them as
+// JSON so they can be updated across sessions.
+type Gradebook struct {
+	Students map[string]*StudentRecord `json:"students"`
+}
+
+// NewGradebook creates an empty gradebook.
+func NewGradebook() *Gradebook {
+	return &Gradebook{Students: make(map[string]*StudentRecord)}
+}
+
+// LoadGradebook reads the gradebook at path. A missing file yields an empty
+// gradebook so the first command creates it.
+func LoadGradebook(path string) (*Gradebook, error) {
+	book := NewGradebook()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return book, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, book); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if book.Students == nil {
+		book.Students = make(map[string]*StudentRecord)
+	}
+	for _, record := range book.Students {
+		if len(record.Courses) > 0 {
+			record.Terms = append([]*Term{{Name: DefaultTerm, Courses: record.Courses}}, record.Terms...)
+		}
+		record.Courses = nil
+		for _, t := range record.Terms {
+			if t.Courses == nil {
+				t.Courses = make(Grades)
+			}
+		}
+	}
+	return book, nil
+}
+
+// Save writes the gradebook to path, replacing the old file only once the
+// new one has been written completely.
+func (b *Gradebook) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// AddStudent adds a student without any courses.
+func (b *Gradebook) AddStudent(student string) error {
+	if _, exists := b.Students[student]; exists {
+		return ErrDuplicateStudent
+	}
+	b.Students[student] = &StudentRecord{}
+	return nil
+}
+
+// AddCourse records a new course for a student in a term, adding the
+// student and the term if needed.
+func (b *Gradebook) AddCourse(student, term, course string, grade CourseGrade) error {
+	if err := grade.Validate(); err != nil {
+		return err
+	}
+	record, exists := b.Students[student]
+	if !exists {
+		record = &StudentRecord{}
+		b.Students[student] = record
+	}
+	t := record.Term(term)
+	if t == nil {
+		t = &Term{Name: term, Courses: make(Grades)}
+		record.Terms = append(record.Terms, t)
+	}
+	return t.Courses.Add(course, grade)
+}
+
+// EditCourse replaces the grade of a course the student has in a term.
+func (b *Gradebook) EditCourse(student, term, course string, grade CourseGrade) error {
+	t, err := b.term(student, term)
+	if err != nil {
+		return err
+	}
+	return t.Courses.Edit(course, grade)
+}
+
+// RenameStudent moves a student's record to a new name.
+func (b *Gradebook) RenameStudent(student, newName string) error {
+	record, exists := b.Students[student]
+	if !exists {
+		return ErrUnknownStudent
+	}
+	if _, exists := b.Students[newName]; exists {
+		return ErrDuplicateStudent
+	}
+	delete(b.Students, student)
+	b.Students[newName] = record
+	return nil
+}
+
+// DeleteStudent removes a student and all their grades.
+func (b *Gradebook) DeleteStudent(student string) error {
+	if _, exists := b.Students[student]; !exists {
+		return ErrUnknownStudent
+	}
+	delete(b.Students, student)
+	return nil
+}
+
+// DeleteCourse removes one course from a term of a student's record. A term
+// left without courses is removed as well.
+func (b *Gradebook) DeleteCourse(student, term, course string) error {
+	t, err := b.term(student, term)
+	if err != nil {
+		return err
+	}
+	if err := t.Courses.Delete(course); err != nil {
+		return err
+	}
+
+	if len(t.Courses) == 0 {
+		record := b.Students[student]
+		terms := record.Terms[:0]
+		for _, other := range record.Terms {
+			if other != t {
+				terms = append(terms, other)
+			}
+		}
+		record.Terms = terms
+	}
+	return nil
+}
+
+// term looks up a term of a student's record.
+func (b *Gradebook) term(student, term string) (*Term, error) {
+	record, exists := b.Students[student]
+	if !exists {
+		return nil, ErrUnknownStudent
+	}
+	t := record.Term(term)
+	if t == nil {
+		return nil, ErrUnknownTerm
+	}
+	return t, nil
+}
+
+// StudentNames returns the students in alphabetical order.
+func (b *Gradebook) StudentNames() []string {
+	names := make([]string, 0, len(b.Students))
+	for name := range b.Students {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Class returns every student's counted courses in the form used by the
+// reports.
+func (b *Gradebook) Class(retake RetakePolicy) Class {
+	class := make(Class, len(b.Students))
+	for name, record := range b.Students {
+		class[name] = record.Grades(retake)
+	}
+	return class
+}
@@ -0,0 +1,226 @@
+// Package grades validates course scores and computes averages, letter
+// grades and GPAs for a single student or a whole class.
+package grades
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// MinScore and MaxScore bound every course and component score.
+	MinScore = 0
+	MaxScore = 100
+	// MaxCredits is the most credit hours a single course can be worth.
+	MaxCredits = 30
+	// DefaultCredits is used when a course is given without credit hours.
+	DefaultCredits = 1
+)
+
+// CourseGrade is the score a student got in a course together with the
+// credit hours the course is worth. When the course is graded from
+// components, Score is derived from them.
+type CourseGrade struct {
+	Score      float64     `json:"score"`
+	Credits    float64     `json:"credits"`
+	Components []Component `json:"components,omitempty"`
+}
+
+// Validate checks the score and the credit hours of the grade.
+func (g CourseGrade) Validate() error {
+	if err := CheckScore(g.Score); err != nil {
+		return err
+	}
+	return CheckCredits(g.Credits)
+}
+
+// Grades holds one student's grades keyed by course.
+type Grades map[string]CourseGrade
+
+// Class holds the grades of many students keyed by student name.
+type Class map[string]Grades
+
+// Check reports whether a score is between MinScore and MaxScore.
+func Check(score float64) bool {
+	return score >= MinScore && score <= MaxScore
+}
+
+// CheckScore is Check returning a *ScoreRangeError for invalid scores.
+func CheckScore(score float64) error {
+	if !Check(score) {
+		return &ScoreRangeError{Score: score}
+	}
+	return nil
+}
+
+// CheckCredits returns a *CreditsRangeError unless credits is greater than 0
+// and at most MaxCredits.
+func CheckCredits(credits float64) error {
+	if credits <= 0 || credits > MaxCredits {
+		return &CreditsRangeError{Credits: credits}
+	}
+	return nil
+}
+
+// Average returns the plain mean of the scores, or 0 when there are none.
+func Average(scores map[string]float64) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, score := range scores {
+		total += score
+	}
+	return total / float64(len(scores))
+}
+
+// Add records a new course after validating its grade. It returns a
+// *DuplicateCourseError if the course is already recorded.
+func (g Grades) Add(course string, grade CourseGrade) error {
+	if _, exists := g[course]; exists {
+		return &DuplicateCourseError{Course: course}
+	}
+	if err := grade.Validate(); err != nil {
+		return err
+	}
+	g[course] = grade
+	return nil
+}
+
+// Edit replaces the grade of a recorded course. It returns an
+// *UnknownCourseError if the course has not been recorded.
+func (g Grades) Edit(course string, grade CourseGrade) error {
+	if _, exists := g[course]; !exists {
+		return &UnknownCourseError{Course: course}
+	}
+	if err := grade.Validate(); err != nil {
+		return err
+	}
+	g[course] = grade
+	return nil
+}
+
+// Delete removes a course. It returns an *UnknownCourseError if the course
+// has not been recorded.
+func (g Grades) Delete(course string) error {
+	if _, exists := g[course]; !exists {
+		return &UnknownCourseError{Course: course}
+	}
+	delete(g, course)
+	return nil
+}
+
+// Scores drops the credit hours so the grades can be fed to Average.
+func (g Grades) Scores() map[string]float64 {
+	plain := make(map[string]float64, len(g))
+	for course, grade := range g {
+		plain[course] = grade.Score
+	}
+	return plain
+}
+
+// Rounded returns a copy of the grades with every score rounded by r, the
+// form in which scores are graded and averaged.
+func (g Grades) Rounded(r Rounding) Grades {
+	rounded := make(Grades, len(g))
+	for course, grade := range g {
+		grade.Score = r.Round(grade.Score)
+		rounded[course] = grade
+	}
+	return rounded
+}
+
+// Average returns the plain mean of the course scores.
+func (g Grades) Average() float64 {
+	return Average(g.Scores())
+}
+
+// WeightedAverage averages the scores weighted by credit hours.
+func (g Grades) WeightedAverage() float64 {
+	return WeightedAverage(g.Scores(), g)
+}
+
+// GPA returns the credit-weighted grade point average on the given scale.
+func (g Grades) GPA(scale Scale) float64 {
+	return WeightedGPA(g.Scores(), g, scale)
+}
+
+// WeightedAverage averages course scores weighted by the credit hours in
+// grades. Courses without a score are left out.
+func WeightedAverage(scores map[string]float64, grades Grades) float64 {
+	var total, credits float64
+	for course, score := range scores {
+		total += score * grades[course].Credits
+		credits += grades[course].Credits
+	}
+	if credits == 0 {
+		return 0
+	}
+	return total / credits
+}
+
+// WeightedGPA is the grade point counterpart of WeightedAverage.
+func WeightedGPA(scores map[string]float64, grades Grades, scale Scale) float64 {
+	var points, credits float64
+	for course, score := range scores {
+		points += scale.Lookup(score).Points * grades[course].Credits
+		credits += grades[course].Credits
+	}
+	if credits == 0 {
+		return 0
+	}
+	return points / credits
+}
+
+// ParseGrade validates a score and optional credit hours given as text.
+// Both accept a decimal point or comma.
+func ParseGrade(scoreField, creditsField string) (CourseGrade, error) {
+	score, err := ParseScore(scoreField)
+	if err != nil {
+		return CourseGrade{}, fmt.Errorf("score: %w", err)
+	}
+	credits, err := ParseCredits(creditsField)
+	if err != nil {
+		return CourseGrade{}, err
+	}
+
+	grade := CourseGrade{Score: score, Credits: credits}
+	if err := grade.Validate(); err != nil {
+		return CourseGrade{}, err
+	}
+	return grade, nil
+}
+
+// ParseCredits reads credit hours given as text. Empty credits fall back to
+// DefaultCredits.
+func ParseCredits(field string) (float64, error) {
+	if strings.TrimSpace(field) == "" {
+		return DefaultCredits, nil
+	}
+	credits, err := ParseScore(field)
+	if err != nil {
+		return 0, fmt.Errorf("credits: %w", err)
+	}
+	return credits, CheckCredits(credits)
+}
+
+// StudentNames returns the students in alphabetical order.
+func (c Class) StudentNames() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Rounded returns a copy of the class with every score rounded by r.
+func (c Class) Rounded(r Rounding) Class {
+	rounded := make(Class, len(c))
+	for name, grades := range c {
+		rounded[name] = grades.Rounded(r)
+	}
+	return rounded
+}
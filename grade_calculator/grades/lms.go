@@ -0,0 +1,233 @@
+package grades
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Importer reads a class from one gradebook export format.
+type Importer interface {
+	Import(r io.Reader) (Class, []RejectedRow, error)
+}
+
+// Importers maps input format names to their importers.
+var Importers = map[string]Importer{
+	"csv":    CSVImporter{},
+	"canvas": CanvasImporter{},
+	"moodle": MoodleImporter{},
+}
+
+// CSVImporter reads the calculator's own student,course,score[,credits]
+// rows with ReadCSV.
+type CSVImporter struct{}
+
+func (CSVImporter) Import(r io.Reader) (Class, []RejectedRow, error) {
+	return ReadCSV(r)
+}
+
+// ungraded reports whether an LMS cell holds no grade yet: empty, a dash,
+// or excused.
+func ungraded(cell string) bool {
+	switch strings.ToUpper(strings.TrimSpace(cell)) {
+	case "", "-", "EX":
+		return true
+	}
+	return false
+}
+
+// addImported records one imported score as a course worth DefaultCredits,
+// adding a rejected row when it fails validation.
+func addImported(class Class, rejected *[]RejectedRow, line int, student, course string, score float64, cell string) {
+	grade := CourseGrade{Score: score, Credits: DefaultCredits}
+	err := grade.Validate()
+	if err == nil {
+		if class[student] == nil {
+			class[student] = make(Grades)
+		}
+		err = class[student].Add(course, grade)
+	}
+	if err != nil {
+		*rejected = append(*rejected, RejectedRow{Line: line, Record: []string{student, course, cell}, Reason: err.Error()})
+	}
+}
+
+// lmsColumn is a gradebook column imported as a course.
+type lmsColumn struct {
+	index    int
+	course   string
+	realOnly bool // a Moodle item exported without its Percentage column
+}
+
+// canvasAssignment matches Canvas assignment headers such as
+// "Homework 1 (48213)"; summary columns carry no assignment id.
+var canvasAssignment = regexp.MustCompile(`^(.+) \((\d+)\)$`)
+
+// CanvasImporter reads a Canvas gradebook export. The "Student" column
+// names the student and every assignment column becomes a course. Scores
+// are points, turned into percentages with the "Points Possible" row, so
+// extra credit above full marks is rejected by the range check.
+type CanvasImporter struct{}
+
+func (CanvasImporter) Import(r io.Reader) (Class, []RejectedRow, error) {
+	reader := newCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading Canvas header: %w", err)
+	}
+	if len(header) == 0 || strings.TrimSpace(strings.TrimPrefix(header[0], "\ufeff")) != "Student" {
+		return nil, nil, errors.New(`not a Canvas export: the first column must be "Student"`)
+	}
+
+	var columns []lmsColumn
+	for i, column := range header {
+		if m := canvasAssignment.FindStringSubmatch(strings.TrimSpace(column)); m != nil {
+			columns = append(columns, lmsColumn{index: i, course: m[1]})
+		}
+	}
+
+	class := make(Class)
+	var rejected []RejectedRow
+	var possible []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if row, ok := parseErrorRow(err, record); ok {
+				rejected = append(rejected, row)
+				continue
+			}
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		student := strings.TrimSpace(record[0])
+		switch student {
+		case "Points Possible":
+			possible = record
+			continue
+		case "", "Student, Test":
+			// Canvas adds rows of column notes and a test student.
+			continue
+		}
+
+		for _, column := range columns {
+			i, course := column.index, column.course
+			if i >= len(record) || ungraded(record[i]) {
+				continue
+			}
+			cell := strings.TrimSpace(record[i])
+			points, err := ParseScore(cell)
+			if err != nil {
+				rejected = append(rejected, RejectedRow{Line: line, Record: []string{student, course, cell}, Reason: "score: " + err.Error()})
+				continue
+			}
+			if i >= len(possible) {
+				rejected = append(rejected, RejectedRow{Line: line, Record: []string{student, course, cell}, Reason: "no Points Possible row before this student"})
+				continue
+			}
+			total, err := ParseScore(possible[i])
+			if err != nil || total <= 0 {
+				rejected = append(rejected, RejectedRow{Line: line, Record: []string{student, course, cell}, Reason: fmt.Sprintf("points possible %q is not a positive number", strings.TrimSpace(possible[i]))})
+				continue
+			}
+			addImported(class, &rejected, line, student, course, points/total*100, cell)
+		}
+	}
+	return class, rejected, nil
+}
+
+// moodleItem matches Moodle grade item headers such as
+// "Assignment: Essay (Real)" or "Quiz: Quiz 1 (Percentage)".
+var moodleItem = regexp.MustCompile(`^(?:[^:]+: )?(.+) \((Real|Percentage)\)$`)
+
+// MoodleImporter reads a Moodle grader report export. The "First name" and
+// "Last name" (or "Surname") columns name the student and every grade item
+// becomes a course, read from its Percentage column. Moodle does not export
+// the item maximum, so an item with only a Real column cannot be turned into
+// a percentage and its scores are rejected. The course total is skipped.
+type MoodleImporter struct{}
+
+func (MoodleImporter) Import(r io.Reader) (Class, []RejectedRow, error) {
+	reader := newCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading Moodle header: %w", err)
+	}
+
+	first, last := -1, -1
+	var columns []lmsColumn
+	byCourse := make(map[string]int)
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		switch column {
+		case "First name":
+			first = i
+		case "Last name", "Surname":
+			last = i
+		}
+		m := moodleItem.FindStringSubmatch(column)
+		if m == nil || strings.HasPrefix(m[1], "Course total") {
+			continue
+		}
+		prev, seen := byCourse[m[1]]
+		switch {
+		case !seen:
+			byCourse[m[1]] = len(columns)
+			columns = append(columns, lmsColumn{index: i, course: m[1], realOnly: m[2] == "Real"})
+		case m[2] == "Percentage":
+			columns[prev].index, columns[prev].realOnly = i, false
+		}
+	}
+	if first < 0 || last < 0 {
+		return nil, nil, errors.New(`not a Moodle export: expected "First name" and "Last name" columns`)
+	}
+
+	class := make(Class)
+	var rejected []RejectedRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if row, ok := parseErrorRow(err, record); ok {
+				rejected = append(rejected, row)
+				continue
+			}
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if first >= len(record) || last >= len(record) {
+			rejected = append(rejected, RejectedRow{Line: line, Record: record, Reason: "row has fewer columns than the header"})
+			continue
+		}
+		student := strings.TrimSpace(strings.TrimSpace(record[first]) + " " + strings.TrimSpace(record[last]))
+		if student == "" {
+			continue
+		}
+
+		for _, column := range columns {
+			i, course := column.index, column.course
+			if i >= len(record) || ungraded(record[i]) {
+				continue
+			}
+			cell := strings.TrimSpace(record[i])
+			if column.realOnly {
+				rejected = append(rejected, RejectedRow{Line: line, Record: []string{student, course, cell}, Reason: "only a Real value was exported and the item maximum is unknown; export grades as Percentage"})
+				continue
+			}
+			score, err := ParseScore(strings.TrimSpace(strings.TrimSuffix(cell, "%")))
+			if err != nil {
+				rejected = append(rejected, RejectedRow{Line: line, Record: []string{student, course, cell}, Reason: "score: " + err.Error()})
+				continue
+			}
+			addImported(class, &rejected, line, student, course, score, cell)
+		}
+	}
+	return class, rejected, nil
+}
@@ -0,0 +1,144 @@
+package grades
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Plan tells what is needed on the remaining work to reach a target.
+type Plan struct {
+	Target    float64
+	Current   float64  // result over the work graded so far
+	Best      float64  // result with full marks on everything remaining
+	Remaining []string // names of the ungraded courses or components
+	Required  float64  // lowest score needed on every remaining item
+	Reachable bool
+	Secured   bool // the target is met even with 0 on everything remaining
+}
+
+// ParseTarget reads a target given either as a score or as a letter grade
+// of the scale, in which case the lowest score of that letter is the target.
+func ParseTarget(target string, scale Scale) (float64, error) {
+	target = strings.TrimSpace(target)
+	if score, err := ParseScore(target); err == nil {
+		if score < MinScore || score > MaxScore {
+			return 0, fmt.Errorf("target %g must be between %d and %d", score, MinScore, MaxScore)
+		}
+		return score, nil
+	}
+	for _, band := range scale {
+		if strings.EqualFold(band.Letter, target) {
+			return band.Min, nil
+		}
+	}
+	return 0, fmt.Errorf("target %q is neither a score nor a letter grade", target)
+}
+
+// PlanAverage finds the lowest score needed on each remaining course, given
+// by its credit hours, for the credit-weighted average to reach target once
+// scores and average are rounded by r.
+func PlanAverage(scored Grades, remaining map[string]float64, target float64, r Rounding) (Plan, error) {
+	for course, grade := range scored {
+		if err := grade.Validate(); err != nil {
+			return Plan{}, fmt.Errorf("%s: %w", course, err)
+		}
+	}
+	for course, credits := range remaining {
+		if _, exists := scored[course]; exists {
+			return Plan{}, &DuplicateCourseError{Course: course}
+		}
+		if err := CheckCredits(credits); err != nil {
+			return Plan{}, fmt.Errorf("%s: %w", course, err)
+		}
+	}
+
+	average := func(score float64) float64 {
+		all := make(Grades, len(scored)+len(remaining))
+		for course, grade := range scored {
+			all[course] = grade
+		}
+		for course, credits := range remaining {
+			all[course] = CourseGrade{Score: score, Credits: credits}
+		}
+		return r.Round(all.Rounded(r).WeightedAverage())
+	}
+	current := r.Round(scored.Rounded(r).WeightedAverage())
+	return plan(target, current, sortedKeys(remaining), r, average), nil
+}
+
+// PlanCourse finds the lowest score needed on each ungraded component for
+// the course score, rounded by r, to reach target. Missing components count
+// as zero until they are graded, as with MissingZero.
+func PlanCourse(components []Component, target float64, r Rounding) (Plan, error) {
+	if err := ValidateComponents(components); err != nil {
+		return Plan{}, err
+	}
+
+	var remaining []string
+	var graded float64
+	for _, c := range components {
+		if c.Score == nil {
+			remaining = append(remaining, c.Name)
+		} else {
+			graded += c.Weight
+		}
+	}
+
+	current := 0.0
+	if graded > 0 {
+		score, err := ComponentScore(components, MissingReweight)
+		if err != nil {
+			return Plan{}, err
+		}
+		current = r.Round(score)
+	}
+	courseScore := func(score float64) float64 {
+		filled := make([]Component, len(components))
+		for i, c := range components {
+			filled[i] = c
+			if c.Score == nil {
+				filled[i].Score = &score
+			}
+		}
+		result, _ := ComponentScore(filled, MissingZero)
+		return r.Round(result)
+	}
+	return plan(target, current, remaining, r, courseScore), nil
+}
+
+// plan searches for the lowest score, in steps of the rounding precision,
+// that given to every remaining item makes result reach target. result must
+// not decrease as the score grows.
+func plan(target, current float64, remaining []string, r Rounding, result func(score float64) float64) Plan {
+	p := Plan{Target: target, Current: current, Remaining: remaining, Best: result(MaxScore)}
+	if len(remaining) == 0 {
+		p.Best = current
+		p.Reachable = current >= target
+		p.Secured = p.Reachable
+		return p
+	}
+	if p.Best < target {
+		return p
+	}
+
+	step := math.Pow(10, -float64(r.Places))
+	units := int(math.Round((MaxScore - MinScore) / step))
+	lowest := sort.Search(units+1, func(i int) bool {
+		return result(MinScore+float64(i)*step) >= target
+	})
+	p.Required = r.Round(MinScore + float64(lowest)*step)
+	p.Reachable = true
+	p.Secured = lowest == 0
+	return p
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
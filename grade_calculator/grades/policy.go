@@ -0,0 +1,156 @@
+package grades
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Adjusted holds scores after grading policies, keyed like Class. A course
+// missing from a student's map was dropped.
+type Adjusted map[string]map[string]float64
+
+// Policy adjusts the scores of a whole class in place. Implementations can
+// be combined freely; ApplyPolicies runs them in order.
+type Policy interface {
+	Apply(scores Adjusted)
+}
+
+// DropLowest drops each student's N lowest scores, always keeping at least
+// one course.
+type DropLowest struct {
+	N int
+}
+
+func (p DropLowest) Apply(scores Adjusted) {
+	for _, courses := range scores {
+		names := make([]string, 0, len(courses))
+		for course := range courses {
+			names = append(names, course)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if courses[names[i]] != courses[names[j]] {
+				return courses[names[i]] < courses[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		for i := 0; i < p.N && i < len(names)-1; i++ {
+			delete(courses, names[i])
+		}
+	}
+}
+
+// LinearScale multiplies each course's scores so its top score becomes 100.
+type LinearScale struct{}
+
+func (LinearScale) Apply(scores Adjusted) {
+	top := make(map[string]float64)
+	for _, courses := range scores {
+		for course, score := range courses {
+			if score > top[course] {
+				top[course] = score
+			}
+		}
+	}
+	for _, courses := range scores {
+		for course, score := range courses {
+			if top[course] > 0 {
+				courses[course] = score * MaxScore / top[course]
+			}
+		}
+	}
+}
+
+// BellCurve normalizes each course's scores to a target mean and standard
+// deviation. Courses whose scores do not vary are left alone.
+type BellCurve struct {
+	Mean   float64
+	StdDev float64
+}
+
+func (p BellCurve) Apply(scores Adjusted) {
+	byCourse := make(map[string][]float64)
+	for _, courses := range scores {
+		for course, score := range courses {
+			byCourse[course] = append(byCourse[course], score)
+		}
+	}
+	for _, courses := range scores {
+		for course, score := range courses {
+			sd := StdDev(byCourse[course])
+			if sd == 0 {
+				continue
+			}
+			z := (score - Mean(byCourse[course])) / sd
+			courses[course] = math.Max(MinScore, math.Min(MaxScore, p.Mean+z*p.StdDev))
+		}
+	}
+}
+
+// BonusPoints adds Points to every score without going over Cap, which is
+// never above MaxScore.
+type BonusPoints struct {
+	Points float64
+	Cap    float64
+}
+
+func (p BonusPoints) Apply(scores Adjusted) {
+	for _, courses := range scores {
+		for course, score := range courses {
+			limit := math.Min(p.Cap, MaxScore)
+			if score < limit {
+				courses[course] = math.Min(score+p.Points, limit)
+			}
+		}
+	}
+}
+
+// ParsePolicies parses a comma-separated policy list such as
+// "drop:1,curve:75:10,bonus:5:100". Policies run in the order given.
+func ParsePolicies(spec string) ([]Policy, error) {
+	var policies []Policy
+	if strings.TrimSpace(spec) == "" {
+		return policies, nil
+	}
+
+	for _, item := range strings.Split(spec, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		values := make([]float64, len(parts)-1)
+		for i, part := range parts[1:] {
+			v, err := ParseScore(part)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %q is not a number", item, part)
+			}
+			values[i] = v
+		}
+
+		switch {
+		case parts[0] == "drop" && len(values) == 1 && values[0] >= 0 && values[0] == math.Trunc(values[0]):
+			policies = append(policies, DropLowest{N: int(values[0])})
+		case parts[0] == "linear" && len(values) == 0:
+			policies = append(policies, LinearScale{})
+		case parts[0] == "curve" && len(values) == 2 && values[0] >= MinScore && values[0] <= MaxScore && values[1] > 0:
+			policies = append(policies, BellCurve{Mean: values[0], StdDev: values[1]})
+		case parts[0] == "bonus" && len(values) == 1 && values[0] >= 0:
+			policies = append(policies, BonusPoints{Points: values[0], Cap: MaxScore})
+		case parts[0] == "bonus" && len(values) == 2 && values[0] >= 0 && values[1] > 0 && values[1] <= MaxScore:
+			policies = append(policies, BonusPoints{Points: values[0], Cap: values[1]})
+		default:
+			return nil, fmt.Errorf("unknown policy %q", item)
+		}
+	}
+	return policies, nil
+}
+
+// ApplyPolicies runs the policies over a copy of the class's scores.
+func ApplyPolicies(class Class, policies []Policy) Adjusted {
+	scores := make(Adjusted, len(class))
+	for name, grades := range class {
+		scores[name] = grades.Scores()
+	}
+	for _, policy := range policies {
+		policy.Apply(scores)
+	}
+	return scores
+}
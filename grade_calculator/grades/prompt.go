@@ -0,0 +1,115 @@
+package grades
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RetryPolicy says how many more times the prompt asks after an invalid
+// answer before giving up with ErrTooManyAttempts.
+type RetryPolicy struct {
+	Attempts int
+}
+
+// DefaultRetryPolicy gives the user three more tries.
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3}
+
+// Prompt asks questions on out and reads one answer per line from in.
+type Prompt struct {
+	in    *bufio.Scanner
+	out   io.Writer
+	retry RetryPolicy
+}
+
+// NewPrompt creates a prompt that retries invalid scores and credit hours
+// according to retry.
+func NewPrompt(in io.Reader, out io.Writer, retry RetryPolicy) *Prompt {
+	return &Prompt{in: bufio.NewScanner(in), out: out, retry: retry}
+}
+
+// Ask prints the question and returns the trimmed answer. At the end of the
+// input the answer is empty.
+func (p *Prompt) Ask(question string) string {
+	fmt.Fprintln(p.out, question)
+	if !p.in.Scan() {
+		return ""
+	}
+	return strings.TrimSpace(p.in.Text())
+}
+
+// AskInt is Ask for a whole number. Anything else reads as 0.
+func (p *Prompt) AskInt(question string) int {
+	n, _ := strconv.Atoi(p.Ask(question))
+	return n
+}
+
+// AskScore asks for the score of a course, asking again while the answer
+// is not a valid score. Decimals may be written with a point or a comma.
+func (p *Prompt) AskScore(course string) (float64, error) {
+	score, err := ParseScore(p.Ask(fmt.Sprintf("Please enter the score you got for %s", course)))
+	if err == nil && Check(score) {
+		return score, nil
+	}
+
+	fmt.Fprintln(p.out, "Invalid score! Score must be between 0 and 100.")
+	for attempts := 0; attempts < p.retry.Attempts; attempts++ {
+		score, err = ParseScore(p.Ask("Please enter a valid score between 0 and 100:"))
+		if err == nil && Check(score) {
+			return score, nil
+		}
+		fmt.Fprintln(p.out, "Invalid input. Try again.")
+	}
+	return 0, ErrTooManyAttempts
+}
+
+// AskCredits asks for the credit hours of a course, asking again while the
+// answer is not valid.
+func (p *Prompt) AskCredits(course string) (float64, error) {
+	credits, err := ParseScore(p.Ask(fmt.Sprintf("Please enter the credit hours for %s", course)))
+	if err == nil && CheckCredits(credits) == nil {
+		return credits, nil
+	}
+
+	fmt.Fprintln(p.out, "Invalid credit hours! Credits must be greater than 0 and at most 30.")
+	for attempts := 0; attempts < p.retry.Attempts; attempts++ {
+		credits, err = ParseScore(p.Ask("Please enter valid credit hours:"))
+		if err == nil && CheckCredits(credits) == nil {
+			return credits, nil
+		}
+		fmt.Fprintln(p.out, "Invalid input. Try again.")
+	}
+	return 0, ErrTooManyAttempts
+}
+
+// AskComponents asks for the name, weight and score of count components.
+// An empty or "-" score marks a component that has not been graded yet; a
+// component whose score stays invalid after the retries is left ungraded.
+func (p *Prompt) AskComponents(count int) []Component {
+	components := make([]Component, 0, count)
+	for i := 0; i < count; i++ {
+		c := Component{Name: p.Ask("Please enter the component name (e.g. midterm):")}
+		c.Weight, _ = ParseScore(p.Ask(fmt.Sprintf("Please enter the weight of %s in percent:", c.Name)))
+
+		for attempts := 0; ; attempts++ {
+			input := p.Ask(fmt.Sprintf("Please enter the score you got for %s (leave empty if not graded yet):", c.Name))
+			if input == "" || input == "-" {
+				break
+			}
+			score, err := ParseScore(input)
+			if err == nil && Check(score) {
+				c.Score = &score
+				break
+			}
+			if attempts == p.retry.Attempts {
+				fmt.Fprintln(p.out, "Too many invalid attempts. Leaving", c.Name, "ungraded.")
+				break
+			}
+			fmt.Fprintln(p.out, "Invalid score! Score must be between 0 and 100.")
+		}
+		components = append(components, c)
+	}
+	return components
+}
@@ -0,0 +1,163 @@
+package grades
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HonoursBand awards Name to every average at or above Min.
+type HonoursBand struct {
+	Min  float64
+	Name string
+}
+
+// Honours is a list of honours bands ordered from the highest Min down. An
+// average below every band gets no honours.
+type Honours []HonoursBand
+
+// DefaultHonours are the bands of the -honours flag unless it is given.
+var DefaultHonours = Honours{
+	{85, "distinction"},
+	{70, "merit"},
+	{50, "pass"},
+}
+
+// DefaultAtRisk is the average below which a student is flagged as at risk.
+const DefaultAtRisk = 60
+
+// ParseHonours reads honours bands given as "name:min" pairs, e.g.
+// "distinction:85,merit:70,pass:50". An empty spec awards no honours.
+func ParseHonours(spec string) (Honours, error) {
+	var honours Honours
+	if strings.TrimSpace(spec) == "" {
+		return honours, nil
+	}
+
+	for _, item := range strings.Split(spec, ",") {
+		name, minText, found := strings.Cut(strings.TrimSpace(item), ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("honours band %q must be <name>:<min average>", item)
+		}
+		minScore, err := ParseScore(minText)
+		if err != nil || !Check(minScore) {
+			return nil, fmt.Errorf("%s: minimum average %q must be between %d and %d", name, minText, MinScore, MaxScore)
+		}
+		honours = append(honours, HonoursBand{Min: minScore, Name: name})
+	}
+	sort.SliceStable(honours, func(i, j int) bool { return honours[i].Min > honours[j].Min })
+	return honours, nil
+}
+
+// String lists the bands in the form ParseHonours reads.
+func (h Honours) String() string {
+	bands := make([]string, len(h))
+	for i, band := range h {
+		bands[i] = band.Name + ":" + FormatScore(band.Min)
+	}
+	return strings.Join(bands, ",")
+}
+
+// Classify returns the honours an average earns, or "" when it is below
+// every band.
+func (h Honours) Classify(average float64) string {
+	for _, band := range h {
+		if average >= band.Min {
+			return band.Name
+		}
+	}
+	return ""
+}
+
+// Standing is a student's place in the class. Students with equal averages
+// share a rank and the next rank is skipped, so two students tied for
+// second are followed by the fourth.
+type Standing struct {
+	Rank    int    `json:"rank"`
+	Of      int    `json:"of"`
+	Tied    bool   `json:"tied,omitempty"`
+	Honours string `json:"honours,omitempty"`
+	AtRisk  bool   `json:"at_risk,omitempty"`
+}
+
+// rankedAverage is the average a report is ranked by: the adjusted one when
+// grading policies are in use.
+func (report Report) rankedAverage() float64 {
+	if report.Adjusted != nil {
+		return report.Adjusted.Average
+	}
+	return report.Average
+}
+
+// RankReports sets the standing of every report with at least one course,
+// ranking by average, awarding honours and flagging averages below atRisk.
+// Averages are compared as reported, after rounding.
+func RankReports(reports []Report, honours Honours, atRisk float64) {
+	var graded []*Report
+	for i := range reports {
+		reports[i].Standing = nil
+		if len(reports[i].Courses) > 0 {
+			graded = append(graded, &reports[i])
+		}
+	}
+	sort.SliceStable(graded, func(i, j int) bool { return graded[i].rankedAverage() > graded[j].rankedAverage() })
+
+	for i, report := range graded {
+		average := report.rankedAverage()
+		standing := &Standing{
+			Rank:    i + 1,
+			Of:      len(graded),
+			Honours: honours.Classify(average),
+			AtRisk:  average < atRisk,
+		}
+		if i > 0 && graded[i-1].rankedAverage() == average {
+			standing.Rank = graded[i-1].Standing.Rank
+			standing.Tied = true
+			graded[i-1].Standing.Tied = true
+		}
+		report.Standing = standing
+	}
+}
+
+// SortByRank orders reports by rank, then by name, with unranked students
+// last.
+func SortByRank(reports []Report) {
+	sort.SliceStable(reports, func(i, j int) bool {
+		a, b := reports[i].Standing, reports[j].Standing
+		switch {
+		case a == nil || b == nil:
+			return b == nil && a != nil
+		case a.Rank != b.Rank:
+			return a.Rank < b.Rank
+		default:
+			return reports[i].Name < reports[j].Name
+		}
+	})
+}
+
+// standingText describes a report's standing for the text and markdown
+// renderers, one line each.
+func (report Report) standingText() []string {
+	st := report.Standing
+	if st == nil {
+		return nil
+	}
+	var lines []string
+	if st.Of > 1 {
+		rank := fmt.Sprintf("Rank: %d of %d", st.Rank, st.Of)
+		if st.Tied {
+			rank += " (tied)"
+		}
+		lines = append(lines, rank)
+	}
+	honours := st.Honours
+	if honours == "" {
+		honours = "none"
+	}
+	lines = append(lines, "Honours: "+honours)
+	if st.AtRisk {
+		lines = append(lines, "At risk: yes")
+	}
+	return lines
+}
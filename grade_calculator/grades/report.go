@@ -0,0 +1,270 @@
+package grades
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// ReportRow is one course in a student report.
+type ReportRow struct {
+	Course   string   `json:"course"`
+	Credits  float64  `json:"credits"`
+	Score    float64  `json:"score"`
+	Adjusted *float64 `json:"adjusted,omitempty"`
+	Dropped  bool     `json:"dropped,omitempty"`
+	Letter   string   `json:"letter,omitempty"`
+}
+
+// AdjustedSummary holds a student's figures after grading policies.
+type AdjustedSummary struct {
+	Average         float64 `json:"average"`
+	WeightedAverage float64 `json:"weighted_average"`
+	GPA             float64 `json:"gpa"`
+}
+
+// Report is everything a report shows about one student. The raw figures
+// are always present; Adjusted is only set when grading policies are in
+// use, and then letters follow the adjusted scores. Decimals is how many
+// decimals the renderers print, matching the rounding policy.
+type Report struct {
+	Name            string           `json:"name"`
+	Courses         []ReportRow      `json:"courses"`
+	Average         float64          `json:"average"`
+	WeightedAverage float64          `json:"weighted_average"`
+	GPA             float64          `json:"gpa"`
+	Adjusted        *AdjustedSummary `json:"adjusted,omitempty"`
+	Standing        *Standing        `json:"standing,omitempty"`
+	Decimals        int              `json:"-"`
+}
+
+// BuildReport assembles a student's report with courses in alphabetical
+// order. Scores are rounded by r before they are graded or averaged, and
+// so are the averages; GPAs keep two decimals. adjusted is nil when no
+// grading policy is in use.
+func BuildReport(name string, grades Grades, adjusted map[string]float64, scale Scale, r Rounding) Report {
+	grades = grades.Rounded(r)
+	report := Report{
+		Name:            name,
+		Courses:         make([]ReportRow, 0, len(grades)),
+		Average:         r.Round(grades.Average()),
+		WeightedAverage: r.Round(grades.WeightedAverage()),
+		GPA:             r.GPA().Round(grades.GPA(scale)),
+		Decimals:        r.Places,
+	}
+
+	for course, grade := range grades {
+		row := ReportRow{Course: course, Credits: grade.Credits, Score: grade.Score}
+		counted := grade.Score
+		if adjusted != nil {
+			score, kept := adjusted[course]
+			row.Dropped = !kept
+			if kept {
+				score = r.Round(score)
+				row.Adjusted = &score
+				counted = score
+			}
+		}
+		if !row.Dropped {
+			row.Letter = scale.Lookup(counted).Letter
+		}
+		report.Courses = append(report.Courses, row)
+	}
+	sort.Slice(report.Courses, func(i, j int) bool { return report.Courses[i].Course < report.Courses[j].Course })
+
+	if adjusted != nil {
+		kept := make(map[string]float64, len(adjusted))
+		for course, score := range adjusted {
+			kept[course] = r.Round(score)
+		}
+		report.Adjusted = &AdjustedSummary{
+			Average:         r.Round(Average(kept)),
+			WeightedAverage: r.Round(WeightedAverage(kept, grades)),
+			GPA:             r.GPA().Round(WeightedGPA(kept, grades, scale)),
+		}
+	}
+	return report
+}
+
+// figure prints one of the report's averages with its decimals.
+func (report Report) figure(v float64) string {
+	return strconv.FormatFloat(v, 'f', report.Decimals, 64)
+}
+
+// Renderer writes student reports in one output format.
+type Renderer interface {
+	Render(w io.Writer, reports []Report) error
+}
+
+// Renderers maps output format names to their renderers.
+var Renderers = map[string]Renderer{
+	"text":     TextRenderer{},
+	"json":     JSONRenderer{},
+	"csv":      CSVRenderer{},
+	"markdown": MarkdownRenderer{},
+}
+
+// TextRenderer writes each student as an aligned table for the terminal.
+type TextRenderer struct{}
+
+func (TextRenderer) Render(w io.Writer, reports []Report) error {
+	for _, report := range reports {
+		fmt.Fprintf(w, "\nStudent Name: %s\n", report.Name)
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if report.Adjusted == nil {
+			fmt.Fprintln(table, "  Course\tCredits\tScore\tLetter")
+		} else {
+			fmt.Fprintln(table, "  Course\tCredits\tScore\tAdjusted\tLetter")
+		}
+		for _, row := range report.Courses {
+			if report.Adjusted == nil {
+				fmt.Fprintf(table, "  %s\t%g\t%s\t%s\n", row.Course, row.Credits, FormatScore(row.Score), row.Letter)
+				continue
+			}
+			fmt.Fprintf(table, "  %s\t%g\t%s\t%s\t%s\n", row.Course, row.Credits, FormatScore(row.Score), report.adjustedText(row), row.Letter)
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "Average grade: %s\n", report.figure(report.Average))
+		if report.Adjusted == nil {
+			fmt.Fprintf(w, "Weighted average: %s\n", report.figure(report.WeightedAverage))
+			fmt.Fprintf(w, "GPA: %.2f\n", report.GPA)
+		} else {
+			fmt.Fprintf(w, "Adjusted average: %s\n", report.figure(report.Adjusted.Average))
+			fmt.Fprintf(w, "Weighted average: %s (raw %s)\n", report.figure(report.Adjusted.WeightedAverage), report.figure(report.WeightedAverage))
+			fmt.Fprintf(w, "GPA: %.2f (raw %.2f)\n", report.Adjusted.GPA, report.GPA)
+		}
+		for _, line := range report.standingText() {
+			fmt.Fprintln(w, line)
+		}
+	}
+	return nil
+}
+
+// adjustedText formats a row's adjusted score for the text and markdown
+// tables.
+func (report Report) adjustedText(row ReportRow) string {
+	if row.Dropped {
+		return "dropped"
+	}
+	if row.Adjusted == nil {
+		return ""
+	}
+	return report.figure(*row.Adjusted)
+}
+
+// JSONRenderer writes all reports as one indented JSON array.
+type JSONRenderer struct{}
+
+func (JSONRenderer) Render(w io.Writer, reports []Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(reports)
+}
+
+// CSVRenderer writes one row per course. The student's summary figures and
+// standing are repeated on each of their rows so every row stands on its own;
+// with grading policies the figures are the adjusted ones the standing is
+// based on.
+type CSVRenderer struct{}
+
+func (CSVRenderer) Render(w io.Writer, reports []Report) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"student", "course", "credits", "score", "adjusted", "letter", "average", "weighted_average", "gpa", "rank", "honours", "at_risk"})
+	for _, report := range reports {
+		average, weighted, gpa := report.Average, report.WeightedAverage, report.GPA
+		if report.Adjusted != nil {
+			average, weighted, gpa = report.Adjusted.Average, report.Adjusted.WeightedAverage, report.Adjusted.GPA
+		}
+		var rank, honours, atRisk string
+		if st := report.Standing; st != nil {
+			rank, honours, atRisk = strconv.Itoa(st.Rank), st.Honours, strconv.FormatBool(st.AtRisk)
+		}
+		for _, row := range report.Courses {
+			writer.Write([]string{
+				report.Name,
+				row.Course,
+				strconv.FormatFloat(row.Credits, 'g', -1, 64),
+				FormatScore(row.Score),
+				report.adjustedText(row),
+				row.Letter,
+				report.figure(average),
+				report.figure(weighted),
+				fmt.Sprintf("%.2f", gpa),
+				rank,
+				honours,
+				atRisk,
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// MarkdownRenderer writes a heading, a table and a summary list per student.
+type MarkdownRenderer struct{}
+
+func (MarkdownRenderer) Render(w io.Writer, reports []Report) error {
+	for i, report := range reports {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "## %s\n\n", markdownEscape(report.Name))
+		if report.Adjusted == nil {
+			fmt.Fprintln(w, "| Course | Credits | Score | Letter |")
+			fmt.Fprintln(w, "| --- | ---: | ---: | --- |")
+		} else {
+			fmt.Fprintln(w, "| Course | Credits | Score | Adjusted | Letter |")
+			fmt.Fprintln(w, "| --- | ---: | ---: | ---: | --- |")
+		}
+		for _, row := range report.Courses {
+			if report.Adjusted == nil {
+				fmt.Fprintf(w, "| %s | %g | %s | %s |\n", markdownEscape(row.Course), row.Credits, FormatScore(row.Score), row.Letter)
+				continue
+			}
+			fmt.Fprintf(w, "| %s | %g | %s | %s | %s |\n", markdownEscape(row.Course), row.Credits, FormatScore(row.Score), report.adjustedText(row), row.Letter)
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "- Average grade: %s\n", report.figure(report.Average))
+		if report.Adjusted == nil {
+			fmt.Fprintf(w, "- Weighted average: %s\n", report.figure(report.WeightedAverage))
+			fmt.Fprintf(w, "- GPA: %.2f\n", report.GPA)
+		} else {
+			fmt.Fprintf(w, "- Adjusted average: %s\n", report.figure(report.Adjusted.Average))
+			fmt.Fprintf(w, "- Weighted average: %s (raw %s)\n", report.figure(report.Adjusted.WeightedAverage), report.figure(report.WeightedAverage))
+			fmt.Fprintf(w, "- GPA: %.2f (raw %.2f)\n", report.Adjusted.GPA, report.GPA)
+		}
+		for _, line := range report.standingText() {
+			fmt.Fprintf(w, "- %s\n", line)
+		}
+	}
+	return nil
+}
+
+// markdownEscape keeps names from breaking the markdown table layout.
+func markdownEscape(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+// BuildReports builds the report of each named student, running the grading
+// policies over the whole class first so curves see every score. Policies
+// work on the rounded course scores.
+func BuildReports(class Class, names []string, scale Scale, policies []Policy, r Rounding) []Report {
+	var adjusted Adjusted
+	if len(policies) > 0 {
+		adjusted = ApplyPolicies(class.Rounded(r), policies)
+	}
+	reports := make([]Report, 0, len(names))
+	for _, name := range names {
+		reports = append(reports, BuildReport(name, class[name], adjusted[name], scale, r))
+	}
+	return reports
+}
@@ -0,0 +1,105 @@
+package grades
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode names how a value is brought to a fixed number of decimals.
+type RoundingMode string
+
+const (
+	// RoundHalfUp rounds halves away from zero, so 89.5 becomes 90.
+	RoundHalfUp RoundingMode = "half-up"
+	// RoundHalfEven rounds halves to the even neighbour, so 89.5 becomes 90
+	// and 88.5 becomes 88.
+	RoundHalfEven RoundingMode = "half-even"
+	// RoundTruncate drops the extra decimals, so 89.9 becomes 89.
+	RoundTruncate RoundingMode = "truncate"
+)
+
+// maxPlaces is the most decimals a rounding policy may keep.
+const maxPlaces = 6
+
+// gpaPlaces is how many decimals a GPA keeps whatever the score decimals,
+// since a GPA rounded to whole points says little.
+const gpaPlaces = 2
+
+// Rounding is the policy applied to course scores before they are graded
+// and to the averages computed from them.
+type Rounding struct {
+	Mode   RoundingMode
+	Places int
+}
+
+// DefaultRounding keeps two decimals and rounds halves up.
+var DefaultRounding = Rounding{Mode: RoundHalfUp, Places: 2}
+
+// ParseRounding validates a rounding mode and number of decimals.
+func ParseRounding(mode string, places int) (Rounding, error) {
+	if places < 0 || places > maxPlaces {
+		return Rounding{}, fmt.Errorf("decimal places must be between 0 and %d", maxPlaces)
+	}
+	switch RoundingMode(mode) {
+	case RoundHalfUp, RoundHalfEven, RoundTruncate:
+		return Rounding{Mode: RoundingMode(mode), Places: places}, nil
+	default:
+		return Rounding{}, fmt.Errorf("unknown rounding mode %q, use half-up, half-even or truncate", mode)
+	}
+}
+
+// Round applies the policy to v. Values within floating point noise of a
+// boundary, such as 89.49999999999999 from summing weights, are treated as
+// lying exactly on it.
+func (r Rounding) Round(v float64) float64 {
+	pow := math.Pow(10, float64(r.Places))
+	scaled := v * pow
+	if half := math.Round(scaled*2) / 2; math.Abs(scaled-half) < 1e-9 {
+		scaled = half
+	}
+
+	switch r.Mode {
+	case RoundHalfEven:
+		scaled = math.RoundToEven(scaled)
+	case RoundTruncate:
+		scaled = math.Trunc(scaled)
+	default:
+		scaled = math.Round(scaled)
+	}
+	return scaled / pow
+}
+
+// GPA returns the policy used for grade point averages: the same mode with
+// gpaPlaces decimals.
+func (r Rounding) GPA() Rounding {
+	return Rounding{Mode: r.Mode, Places: gpaPlaces}
+}
+
+// Format rounds v and prints it with exactly Places decimals.
+func (r Rounding) Format(v float64) string {
+	return strconv.FormatFloat(r.Round(v), 'f', r.Places, 64)
+}
+
+// ParseScore reads a decimal number written with either a decimal point or
+// a decimal comma, so "87.5" and "87,5" are the same score.
+func ParseScore(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if strings.Contains(text, ",") {
+		if strings.Contains(text, ".") || strings.Count(text, ",") > 1 {
+			return 0, fmt.Errorf("%q is not a valid number", text)
+		}
+		text = strings.Replace(text, ",", ".", 1)
+	}
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%q is not a valid number", text)
+	}
+	return v, nil
+}
+
+// FormatScore prints a score without trailing zeros, e.g. 87.5 or 90.
+func FormatScore(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,67 @@
+package grades
+
+import "testing"
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		mode   RoundingMode
+		places int
+		in     float64
+		want   float64
+	}{
+		// The table in docs/documentation.md, with -decimals 0.
+		{RoundHalfUp, 0, 89.5, 90},
+		{RoundHalfUp, 0, 88.5, 89},
+		{RoundHalfUp, 0, 89.9, 90},
+		{RoundHalfEven, 0, 89.5, 90},
+		{RoundHalfEven, 0, 88.5, 88},
+		{RoundHalfEven, 0, 89.9, 90},
+		{RoundTruncate, 0, 89.5, 89},
+		{RoundTruncate, 0, 88.5, 88},
+		{RoundTruncate, 0, 89.9, 89},
+
+		// Floating point noise just below a boundary counts as the boundary.
+		{RoundHalfUp, 0, 89.49999999999999, 90},
+		{RoundHalfEven, 0, 88.50000000000001, 88},
+		{RoundHalfUp, 2, 1.005, 1.01},
+		{RoundHalfEven, 2, 0.125, 0.12},
+		{RoundTruncate, 2, 0.1 + 0.2, 0.3},
+		{RoundTruncate, 2, 89.99999999999999, 90},
+
+		// Values clear of a boundary are not snapped.
+		{RoundHalfUp, 0, 89.4999, 89},
+		{RoundTruncate, 2, 87.659, 87.65},
+		{RoundHalfUp, 2, 87.5, 87.5},
+	}
+	for _, tt := range tests {
+		r := Rounding{Mode: tt.mode, Places: tt.places}
+		if got := r.Round(tt.in); got != tt.want {
+			t.Errorf("%s with %d places: Round(%v) = %v, want %v", tt.mode, tt.places, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRoundingFormat(t *testing.T) {
+	tests := []struct {
+		r    Rounding
+		in   float64
+		want string
+	}{
+		{DefaultRounding, 90, "90.00"},
+		{DefaultRounding, 87.455, "87.46"},
+		{Rounding{Mode: RoundHalfEven, Places: 0}, 88.5, "88"},
+		{Rounding{Mode: RoundTruncate, Places: 1}, 79.99, "79.9"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Format(tt.in); got != tt.want {
+			t.Errorf("%+v: Format(%v) = %q, want %q", tt.r, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRoundingGPAKeepsTwoPlaces(t *testing.T) {
+	r := Rounding{Mode: RoundHalfEven, Places: 0}
+	if got := r.GPA(); got != (Rounding{Mode: RoundHalfEven, Places: 2}) {
+		t.Errorf("GPA() = %+v, want half-even with 2 places", got)
+	}
+}
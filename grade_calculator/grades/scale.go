@@ -0,0 +1,94 @@
+package grades
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Band maps every score at or above Min to a letter grade and the grade
+// points it is worth on a 4.0 scale.
+type Band struct {
+	Min    float64
+	Letter string
+	Points float64
+}
+
+// Scale is a list of grade bands ordered from the highest Min down.
+type Scale []Band
+
+// DefaultScale is the usual US letter grade scale with +/- grades.
+var DefaultScale = Scale{
+	{93, "A", 4.0},
+	{90, "A-", 3.7},
+	{87, "B+", 3.3},
+	{83, "B", 3.0},
+	{80, "B-", 2.7},
+	{77, "C+", 2.3},
+	{73, "C", 2.0},
+	{70, "C-", 1.7},
+	{67, "D+", 1.3},
+	{63, "D", 1.0},
+	{60, "D-", 0.7},
+	{0, "F", 0.0},
+}
+
+// LoadScale reads a grade scale from a text file. Each non-empty line holds
+// a minimum score, a letter and its grade points, e.g. "93 A 4.0". Lines
+// starting with # are comments.
+func LoadScale(path string) (Scale, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var scale Scale
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected \"<min score> <letter> <points>\"", path, lineNo)
+		}
+		minScore, err := ParseScore(fields[0])
+		if err != nil || minScore < MinScore || minScore > MaxScore {
+			return nil, fmt.Errorf("%s:%d: invalid minimum score %q", path, lineNo, fields[0])
+		}
+		points, err := ParseScore(fields[2])
+		if err != nil || points < 0 {
+			return nil, fmt.Errorf("%s:%d: invalid grade points %q", path, lineNo, fields[2])
+		}
+		scale = append(scale, Band{Min: minScore, Letter: fields[1], Points: points})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(scale) == 0 {
+		return nil, fmt.Errorf("%s: grade scale is empty", path)
+	}
+
+	sort.SliceStable(scale, func(i, j int) bool { return scale[i].Min > scale[j].Min })
+	if scale[len(scale)-1].Min > MinScore {
+		return nil, fmt.Errorf("%s: grade scale must have a band starting at %d", path, MinScore)
+	}
+	return scale, nil
+}
+
+// Lookup returns the band a score falls into.
+func (s Scale) Lookup(score float64) Band {
+	for _, band := range s {
+		if score >= band.Min {
+			return band
+		}
+	}
+	return s[len(s)-1]
+}
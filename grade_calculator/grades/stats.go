@@ -0,0 +1,190 @@
+package grades
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// CourseStats summarizes the scores of every student who took a course.
+type CourseStats struct {
+	Course string
+	Count  int
+	Mean   float64
+	Median float64
+	StdDev float64
+	Min    float64
+	Max    float64
+}
+
+// HistogramBand is a range of scores counted together in the histogram. A
+// band runs from Min up to the next band's Min, so 89.5 falls in 80-89.
+type HistogramBand struct {
+	Label string
+	Min   float64
+}
+
+// HistogramBands are the score bands used by the class histogram, lowest first.
+var HistogramBands = []HistogramBand{
+	{"0-59", 0},
+	{"60-69", 60},
+	{"70-79", 70},
+	{"80-89", 80},
+	{"90-100", 90},
+}
+
+// histogramWidth is the length of the longest histogram bar.
+const histogramWidth = 40
+
+// Mean returns the arithmetic mean of values.
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
+// Median returns the middle value, averaging the two middle values when
+// there is an even number of them.
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// StdDev returns the population standard deviation, since a class report
+// covers every student rather than a sample of them.
+func StdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := Mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// PercentileRank returns the percentage of values below value, counting
+// values equal to it as half below.
+func PercentileRank(value float64, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var below, equal int
+	for _, v := range values {
+		if v < value {
+			below++
+		} else if v == value {
+			equal++
+		}
+	}
+	return 100 * (float64(below) + 0.5*float64(equal)) / float64(len(values))
+}
+
+// CourseStatistics computes per-course statistics, ordered by course name.
+func CourseStatistics(class Class) []CourseStats {
+	byCourse := make(map[string][]float64)
+	for _, grades := range class {
+		for course, grade := range grades {
+			byCourse[course] = append(byCourse[course], grade.Score)
+		}
+	}
+
+	stats := make([]CourseStats, 0, len(byCourse))
+	for course, values := range byCourse {
+		st := CourseStats{Course: course, Count: len(values), Min: values[0], Max: values[0]}
+		for _, score := range values {
+			if score < st.Min {
+				st.Min = score
+			}
+			if score > st.Max {
+				st.Max = score
+			}
+		}
+		st.Mean = Mean(values)
+		st.Median = Median(values)
+		st.StdDev = StdDev(values)
+		stats = append(stats, st)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Course < stats[j].Course })
+	return stats
+}
+
+// ScoreHistogram counts every course score in the class by histogram band.
+func ScoreHistogram(class Class) []int {
+	counts := make([]int, len(HistogramBands))
+	for _, grades := range class {
+		for _, grade := range grades {
+			for i := len(HistogramBands) - 1; i >= 0; i-- {
+				if grade.Score >= HistogramBands[i].Min {
+					counts[i]++
+					break
+				}
+			}
+		}
+	}
+	return counts
+}
+
+// WriteClassReport writes course statistics, each student's percentile rank
+// by average and a histogram of all scores. Scores and figures are rounded
+// by r.
+func WriteClassReport(w io.Writer, class Class, r Rounding) {
+	class = class.Rounded(r)
+	fmt.Fprintln(w, "\nClass Report")
+	fmt.Fprintln(w, "Courses:")
+	for _, st := range CourseStatistics(class) {
+		fmt.Fprintf(w, "  %s: n=%d mean=%s median=%s stddev=%s min=%s max=%s\n",
+			st.Course, st.Count, r.Format(st.Mean), r.Format(st.Median), r.Format(st.StdDev), FormatScore(st.Min), FormatScore(st.Max))
+	}
+
+	names := class.StudentNames()
+	averages := make(map[string]float64, len(names))
+	var all []float64
+	for _, name := range names {
+		if len(class[name]) == 0 {
+			continue
+		}
+		averages[name] = r.Round(class[name].Average())
+		all = append(all, averages[name])
+	}
+	fmt.Fprintln(w, "Students:")
+	for _, name := range names {
+		avg, graded := averages[name]
+		if !graded {
+			continue
+		}
+		fmt.Fprintf(w, "  %s: average %s, percentile rank %.1f\n", name, r.Format(avg), PercentileRank(avg, all))
+	}
+
+	counts := ScoreHistogram(class)
+	largest := 0
+	for _, count := range counts {
+		if count > largest {
+			largest = count
+		}
+	}
+	fmt.Fprintln(w, "Score distribution:")
+	for i := len(HistogramBands) - 1; i >= 0; i-- {
+		bar := 0
+		if largest > 0 {
+			bar = (counts[i]*histogramWidth + largest - 1) / largest
+		}
+		fmt.Fprintf(w, "  %6s | %-*s %d\n", HistogramBands[i].Label, histogramWidth, strings.Repeat("#", bar), counts[i])
+	}
+}
@@ -0,0 +1,62 @@
+package grades
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteTranscript writes a student's courses term by term with the term and
+// cumulative averages. Retaken courses are marked and only the attempt
+// chosen by the retake policy counts towards the cumulative figures. Scores
+// and averages are rounded by r, GPAs to two decimals.
+func WriteTranscript(w io.Writer, name string, record *StudentRecord, scale Scale, retake RetakePolicy, r Rounding) {
+	fmt.Fprintf(w, "\nTranscript: %s\n", name)
+	fmt.Fprintf(w, "Retake policy: %s\n", retake)
+	if len(record.Terms) == 0 {
+		fmt.Fprintln(w, "No courses recorded.")
+		return
+	}
+
+	final := record.CountedAttempts(len(record.Terms), retake)
+	taken := make(map[string]bool)
+	for i, t := range record.Terms {
+		fmt.Fprintf(w, "\nTerm: %s\n", t.Name)
+
+		courses := make([]string, 0, len(t.Courses))
+		for course := range t.Courses {
+			courses = append(courses, course)
+		}
+		sort.Strings(courses)
+		for _, course := range courses {
+			grade := t.Courses[course]
+			var notes []string
+			if taken[course] {
+				notes = append(notes, "retake")
+			}
+			if final[course].Term != i {
+				notes = append(notes, "not counted")
+			}
+			score := r.Round(grade.Score)
+			band := scale.Lookup(score)
+			line := fmt.Sprintf("  %s: %s (%s, %g credits)", course, FormatScore(score), band.Letter, grade.Credits)
+			if len(notes) > 0 {
+				line += " [" + strings.Join(notes, ", ") + "]"
+			}
+			fmt.Fprintln(w, line)
+		}
+		for _, course := range courses {
+			taken[course] = true
+		}
+
+		cumulative := make(Grades)
+		for course, a := range record.CountedAttempts(i+1, retake) {
+			cumulative[course] = a.Grade
+		}
+		term := t.Courses.Rounded(r)
+		cumulative = cumulative.Rounded(r)
+		fmt.Fprintf(w, "Term average: %s, GPA: %s\n", r.Format(term.Average()), r.GPA().Format(term.GPA(scale)))
+		fmt.Fprintf(w, "Cumulative average: %s, GPA: %s\n", r.Format(cumulative.Average()), r.GPA().Format(cumulative.GPA(scale)))
+	}
+}
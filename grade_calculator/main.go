@@ -0,0 +1,417 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"grade_calculator/grades"
+)
+
+// runSettings are the command line options shared by every mode.
+type runSettings struct {
+	scale    grades.Scale
+	policies []grades.Policy
+	missing  grades.MissingPolicy
+	term     string
+	retake   grades.RetakePolicy
+	renderer grades.Renderer
+	rounding grades.Rounding
+	importer grades.Importer
+	honours  grades.Honours
+	atRisk   float64
+	byRank   bool
+}
+
+// renderReports writes the report of each named student in the selected
+// format. Students are ranked against the whole class even when only some
+// of them are shown.
+func renderReports(w io.Writer, class grades.Class, names []string, settings runSettings) error {
+	all := grades.BuildReports(class, class.StudentNames(), settings.scale, settings.policies, settings.rounding)
+	grades.RankReports(all, settings.honours, settings.atRisk)
+
+	byName := make(map[string]grades.Report, len(all))
+	for _, report := range all {
+		byName[report.Name] = report
+	}
+	reports := make([]grades.Report, 0, len(names))
+	for _, name := range names {
+		reports = append(reports, byName[name])
+	}
+	if settings.byRank {
+		grades.SortByRank(reports)
+	}
+	return settings.renderer.Render(w, reports)
+}
+
+// diagnostics is where messages that are not part of the report go, so that
+// machine readable output stays clean.
+func diagnostics(settings runSettings) io.Writer {
+	if _, plain := settings.renderer.(grades.TextRenderer); plain {
+		return os.Stdout
+	}
+	return os.Stderr
+}
+
+// runBatch grades every student found in the export at path ("-" for
+// stdin), read with the selected importer, and lists the rows or scores
+// that were rejected. withStats adds the class report.
+func runBatch(path string, settings runSettings, withStats bool) error {
+	var input io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	students, rejected, err := settings.importer.Import(input)
+	if err != nil {
+		return err
+	}
+
+	if err := renderReports(os.Stdout, students, students.StudentNames(), settings); err != nil {
+		return err
+	}
+	diag := diagnostics(settings)
+	if withStats && len(students) > 0 {
+		grades.WriteClassReport(diag, students, settings.rounding)
+	}
+
+	if len(rejected) > 0 {
+		fmt.Fprintf(diag, "\nRejected rows: %d\n", len(rejected))
+		for _, row := range rejected {
+			fmt.Fprintf(diag, "  line %d: %s (%s)\n", row.Line, strings.Join(row.Record, ","), row.Reason)
+		}
+	}
+	return nil
+}
+
+const gradebookUsage = `gradebook commands (courses go into the term given by -term):
+  add <student> [<course> <score> [credits]]
+  add <student> <course> <name>:<weight>=[score]... [credits]
+  edit <student> <new name>
+  edit <student> <course> <score> [credits]
+  edit <student> <course> <name>:<weight>=[score]... [credits]
+  delete <student> [course]
+  list [student]
+  transcript <student>
+  stats`
+
+// runGradebook executes one gradebook command against the file at path and
+// saves the result when the command changed anything.
+func runGradebook(path string, args []string, settings runSettings) error {
+	book, err := grades.LoadGradebook(path)
+	if err != nil {
+		return err
+	}
+
+	command, args := args[0], args[1:]
+	changed := true
+	switch {
+	case command == "add" && len(args) == 1:
+		err = book.AddStudent(args[0])
+	case command == "add" && len(args) >= 3:
+		var grade grades.CourseGrade
+		grade, err = parseCourseArgs(args[2:], settings.missing)
+		if err == nil {
+			err = book.AddCourse(args[0], settings.term, args[1], grade)
+		}
+	case command == "edit" && len(args) == 2:
+		err = book.RenameStudent(args[0], args[1])
+	case command == "edit" && len(args) >= 3:
+		var grade grades.CourseGrade
+		grade, err = parseCourseArgs(args[2:], settings.missing)
+		if err == nil {
+			err = book.EditCourse(args[0], settings.term, args[1], grade)
+		}
+	case command == "delete" && len(args) == 1:
+		err = book.DeleteStudent(args[0])
+	case command == "delete" && len(args) == 2:
+		err = book.DeleteCourse(args[0], settings.term, args[1])
+	case command == "list" && len(args) <= 1:
+		changed = false
+		names := book.StudentNames()
+		if len(args) == 1 {
+			if _, exists := book.Students[args[0]]; !exists {
+				return grades.ErrUnknownStudent
+			}
+			names = args
+		}
+		if len(names) == 0 {
+			fmt.Fprintln(diagnostics(settings), "The gradebook is empty.")
+		}
+		return renderReports(os.Stdout, book.Class(settings.retake), names, settings)
+	case command == "transcript" && len(args) == 1:
+		changed = false
+		record, exists := book.Students[args[0]]
+		if !exists {
+			return grades.ErrUnknownStudent
+		}
+		grades.WriteTranscript(os.Stdout, args[0], record, settings.scale, settings.retake, settings.rounding)
+	case command == "stats" && len(args) == 0:
+		changed = false
+		grades.WriteClassReport(os.Stdout, book.Class(settings.retake), settings.rounding)
+	default:
+		return errors.New(gradebookUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+	if err := book.Save(path); err != nil {
+		return err
+	}
+	fmt.Println("Gradebook updated.")
+	return nil
+}
+
+// parseCourseArgs reads a course grade given either as "<score> [credits]"
+// or as "name:weight=score" components optionally followed by credits.
+func parseCourseArgs(args []string, missing grades.MissingPolicy) (grades.CourseGrade, error) {
+	if strings.Contains(args[0], ":") {
+		return grades.ParseComponentGrade(args, missing)
+	}
+	switch len(args) {
+	case 1:
+		return grades.ParseGrade(args[0], "")
+	case 2:
+		return grades.ParseGrade(args[0], args[1])
+	default:
+		return grades.CourseGrade{}, errors.New(gradebookUsage)
+	}
+}
+
+const planUsage = `planning commands:
+  plan <target score or letter> <course>:<credits>=[score]...
+  plan-course <target score or letter> <component>:<weight>=[score]...
+Leave the score empty for courses or components that are still to come.`
+
+// runPlanner works out the scores needed on the remaining courses, or on the
+// remaining components of one course, to reach a target.
+func runPlanner(args []string, settings runSettings) error {
+	if len(args) < 3 {
+		return errors.New(planUsage)
+	}
+	target, err := grades.ParseTarget(args[1], settings.scale)
+	if err != nil {
+		return err
+	}
+
+	items := make([]grades.Component, 0, len(args)-2)
+	for _, field := range args[2:] {
+		item, err := grades.ParseComponent(field)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+
+	var plan grades.Plan
+	switch args[0] {
+	case "plan":
+		scored := make(grades.Grades)
+		remaining := make(map[string]float64)
+		for _, item := range items {
+			if item.Score == nil {
+				remaining[item.Name] = item.Weight
+				continue
+			}
+			if err := scored.Add(item.Name, grades.CourseGrade{Score: *item.Score, Credits: item.Weight}); err != nil {
+				return err
+			}
+		}
+		plan, err = grades.PlanAverage(scored, remaining, target, settings.rounding)
+	case "plan-course":
+		plan, err = grades.PlanCourse(items, target, settings.rounding)
+	default:
+		return errors.New(planUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Target: %s (%s)\n", args[1], grades.FormatScore(plan.Target))
+	fmt.Printf("Current: %s\n", settings.rounding.Format(plan.Current))
+	switch {
+	case len(plan.Remaining) == 0 && plan.Reachable:
+		fmt.Println("Nothing left to grade, the target is already reached.")
+	case len(plan.Remaining) == 0:
+		fmt.Println("Nothing left to grade, the target was not reached.")
+	case plan.Secured:
+		fmt.Printf("The target is reached even with 0 on %s.\n", strings.Join(plan.Remaining, ", "))
+	case plan.Reachable:
+		fmt.Printf("Required score on each of the remaining items (%s): %s\n", strings.Join(plan.Remaining, ", "), grades.FormatScore(plan.Required))
+	default:
+		fmt.Printf("Unreachable: even 100 on %s only gives %s.\n", strings.Join(plan.Remaining, ", "), settings.rounding.Format(plan.Best))
+	}
+	return nil
+}
+
+func runInteractive(settings runSettings) {
+	prompt := grades.NewPrompt(os.Stdin, os.Stdout, grades.DefaultRetryPolicy)
+
+	name := prompt.Ask("Please enter your name:")
+	subjTaken := prompt.AskInt("Please enter number of courses you have taken:")
+
+	studentInfo := make(grades.Grades)
+
+	for i := 0; i < subjTaken; i++ {
+		course := prompt.Ask("Please enter the course name:")
+		numComponents := prompt.AskInt(fmt.Sprintf("Please enter number of assessment components for %s (0 to enter a single score):", course))
+
+		var grade grades.CourseGrade
+		var err error
+		if numComponents > 0 {
+			grade.Components = prompt.AskComponents(numComponents)
+			grade.Score, err = grades.ComponentScore(grade.Components, settings.missing)
+			if err != nil {
+				fmt.Println("Invalid components:", err)
+				fmt.Println("Skipping this course.")
+				continue
+			}
+			fmt.Printf("Course score for %s: %s\n", course, settings.rounding.Format(grade.Score))
+		} else {
+			grade.Score, err = prompt.AskScore(course)
+			if err != nil {
+				fmt.Println("Too many invalid attempts. Skipping this course.")
+				continue
+			}
+		}
+
+		grade.Credits, err = prompt.AskCredits(course)
+		if err != nil {
+			fmt.Println("Too many invalid attempts. Skipping this course.")
+			continue
+		}
+
+		if err := studentInfo.Add(course, grade); err != nil {
+			fmt.Println("Could not record the course:", err)
+		}
+	}
+
+	if err := renderReports(os.Stdout, grades.Class{name: studentInfo}, []string{name}, settings); err != nil {
+		fmt.Println("Could not print the report:", err)
+	}
+}
+
+func main() {
+	scalePath := flag.String("scale", "", "file with a custom grade scale (\"<min score> <letter> <points>\" per line)")
+	bookPath := flag.String("book", "gradebook.json", "gradebook file used by the gradebook commands")
+	csvPath := flag.String("csv", "", "grade a class from a CSV of student,course,score[,credits] rows (\"-\" reads stdin)")
+	from := flag.String("from", "csv", "format of the -csv file: csv, canvas or moodle")
+	policySpec := flag.String("policy", "", "comma-separated grading policies: drop:<n>, linear, curve:<mean>:<stddev>, bonus:<points>[:<cap>]")
+	format := flag.String("format", "text", "report format: text, json, csv or markdown")
+	missing := flag.String("missing", string(grades.MissingZero), "how ungraded course components count: zero or reweight")
+	term := flag.String("term", grades.DefaultTerm, "term the gradebook commands add, edit and delete courses in")
+	retake := flag.String("retake", string(grades.RetakeLatest), "which attempt of a retaken course counts: latest, best or first")
+	withStats := flag.Bool("stats", false, "print class statistics after the batch reports")
+	honoursSpec := flag.String("honours", grades.DefaultHonours.String(), "honours bands as comma-separated <name>:<min average> pairs, or \"\" for none")
+	atRiskText := flag.String("at-risk", grades.FormatScore(grades.DefaultAtRisk), "flag students whose average is below this")
+	order := flag.String("order", "name", "order of the student reports: name or rank")
+	roundingMode := flag.String("rounding", string(grades.DefaultRounding.Mode), "how scores and averages are rounded: half-up, half-even or truncate")
+	decimals := flag.Int("decimals", grades.DefaultRounding.Places, "decimals kept when rounding scores and averages")
+	flag.Parse()
+
+	scale := grades.DefaultScale
+	if *scalePath != "" {
+		loaded, err := grades.LoadScale(*scalePath)
+		if err != nil {
+			fmt.Println("Could not load grade scale:", err)
+			os.Exit(1)
+		}
+		scale = loaded
+	}
+
+	policies, err := grades.ParsePolicies(*policySpec)
+	if err != nil {
+		fmt.Println("Invalid grading policy:", err)
+		os.Exit(1)
+	}
+	if *missing != string(grades.MissingZero) && *missing != string(grades.MissingReweight) {
+		fmt.Println("Invalid missing component policy:", *missing)
+		os.Exit(1)
+	}
+	renderer, known := grades.Renderers[*format]
+	if !known {
+		fmt.Println("Invalid report format:", *format)
+		os.Exit(1)
+	}
+	honours, err := grades.ParseHonours(*honoursSpec)
+	if err != nil {
+		fmt.Println("Invalid honours bands:", err)
+		os.Exit(1)
+	}
+	atRisk, err := grades.ParseScore(*atRiskText)
+	if err != nil || !grades.Check(atRisk) {
+		fmt.Println("Invalid at-risk average:", *atRiskText)
+		os.Exit(1)
+	}
+	if *order != "name" && *order != "rank" {
+		fmt.Println("Invalid report order:", *order)
+		os.Exit(1)
+	}
+	importer, known := grades.Importers[*from]
+	if !known {
+		fmt.Println("Invalid import format:", *from)
+		os.Exit(1)
+	}
+	switch grades.RetakePolicy(*retake) {
+	case grades.RetakeLatest, grades.RetakeBest, grades.RetakeFirst:
+	default:
+		fmt.Println("Invalid retake policy:", *retake)
+		os.Exit(1)
+	}
+	rounding, err := grades.ParseRounding(*roundingMode, *decimals)
+	if err != nil {
+		fmt.Println("Invalid rounding policy:", err)
+		os.Exit(1)
+	}
+	settings := runSettings{
+		scale:    scale,
+		policies: policies,
+		missing:  grades.MissingPolicy(*missing),
+		term:     *term,
+		retake:   grades.RetakePolicy(*retake),
+		renderer: renderer,
+		rounding: rounding,
+		importer: importer,
+		honours:  honours,
+		atRisk:   atRisk,
+		byRank:   *order == "rank",
+	}
+
+	if *csvPath != "" {
+		if err := runBatch(*csvPath, settings, *withStats); err != nil {
+			fmt.Println("Could not read grades:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "plan" || flag.Arg(0) == "plan-course" {
+		if err := runPlanner(flag.Args(), settings); err != nil {
+			fmt.Println("Planning error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flag.NArg() > 0 {
+		if err := runGradebook(*bookPath, flag.Args(), settings); err != nil {
+			fmt.Println("Gradebook error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	runInteractive(settings)
+}
@@ -0,0 +1,27 @@
+package models
+
+import "grade_calculator/grades"
+
+type CourseInput struct {
+	Course string `json:"course" binding:"required"`
+	// Score is a pointer so that a score of 0 still counts as given.
+	Score   *float64 `json:"score" binding:"required"`
+	Credits float64  `json:"credits"`
+}
+
+type StudentInput struct {
+	Name    string        `json:"name" binding:"required"`
+	Courses []CourseInput `json:"courses" binding:"required,min=1,dive"`
+}
+
+// CourseError explains why one course of a StudentInput was rejected.
+type CourseError struct {
+	Course string `json:"course"`
+	Error  string `json:"error"`
+}
+
+// Student is a stored student together with their graded report.
+type Student struct {
+	ID string `json:"id"`
+	grades.Report
+}
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"grade_calculator/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRouter() *gin.Engine {
+	r := gin.Default()
+
+	api := r.Group("/api")
+	{
+		students := api.Group("/students")
+		{
+			students.GET("/", controllers.GetAllStudents)
+			students.POST("/", controllers.CreateStudent)
+			students.GET("/:id", controllers.GetStudent)
+			students.DELETE("/:id", controllers.DeleteStudent)
+		}
+	}
+	return r
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"log"
+
+	"grade_calculator/router"
+)
+
+func main() {
+	r := router.SetupRouter()
+	log.Println("Grade API running on :8080")
+	r.Run(":8080")
+}
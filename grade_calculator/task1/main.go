@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"grade_calculator/grades"
+)
+
+func main() {
+	prompt := grades.NewPrompt(os.Stdin, os.Stdout, grades.RetryPolicy{Attempts: 5})
+
+	name := prompt.Ask("please enter ur name")
+	numofsubjs := prompt.AskInt("Please enter number of courses you have taken:")
+
+	studentInfo := make(grades.Grades)
+	for i := 0; i < numofsubjs; i++ {
+		course := prompt.Ask("Please enter the course name:")
+
+		score, err := prompt.AskScore(course)
+		if err != nil {
+			fmt.Println("Too many invalid attempts. Skipping this course.")
+			continue
+		}
+
+		if err := studentInfo.Add(course, grades.CourseGrade{Score: score, Credits: grades.DefaultCredits}); err != nil {
+			fmt.Println("Could not record the course:", err)
+		}
+	}
+
+	report := grades.BuildReport(name, studentInfo, nil, grades.DefaultScale, grades.DefaultRounding)
+	fmt.Printf("\nStudent Name: %s\n", report.Name)
+	fmt.Println("Grades:")
+	for _, row := range report.Courses {
+		fmt.Printf("  %s: %s\n", row.Course, grades.FormatScore(row.Score))
+	}
+	fmt.Printf("Average grade: %s\n", grades.DefaultRounding.Format(report.Average))
+}
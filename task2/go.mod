@@ -0,0 +1,5 @@
+module task2
+
+go 1.21
+
+require golang.org/x/text v0.14.0
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"task2/words"
+)
+
+// interactive reports whether standard input is a terminal rather than a
+// pipe or file.
+func interactive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// loadStopWords builds the stop word set from comma-separated language names
+// and stop word files, split by the tokenizer used for counting.
+func loadStopWords(t words.Tokenizer, languages, files string) (words.StopWords, error) {
+	stop := make(words.StopWords)
+	for _, language := range strings.Split(languages, ",") {
+		if strings.TrimSpace(language) == "" {
+			continue
+		}
+		if err := stop.AddLanguage(t, language); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range strings.Split(files, ",") {
+		if strings.TrimSpace(path) == "" {
+			continue
+		}
+		if err := stop.AddFile(t, strings.TrimSpace(path)); err != nil {
+			return nil, err
+		}
+	}
+	return stop, nil
+}
+
+func main() {
+	workers := flag.Int("workers", runtime.NumCPU(), "number of goroutines counting words")
+	chunkKiB := flag.Int("chunk", words.DefaultChunkSize/1024, "size of the chunks input is read in, in KiB")
+	ngram := flag.Int("ngram", 1, "count phrases of this many words (2 for bigrams, 3 for trigrams); phrases never cross sentences")
+	top := flag.Int("top", 0, "show only the N most frequent words (0 shows all)")
+	sortBy := flag.String("sort", string(words.ByFrequency), "order of the output: freq or alpha")
+	minCount := flag.Int("min", 1, "leave out words seen fewer times than this")
+	languages := flag.String("lang", "", "comma-separated built-in stop word lists to leave out: "+strings.Join(words.Languages(), ", "))
+	stopFiles := flag.String("stopwords", "", "comma-separated files of extra stop words, one or more per line")
+	apostrophes := flag.Bool("apostrophes", false, "keep words with apostrophes such as don't together")
+	hyphens := flag.Bool("hyphens", false, "keep hyphenated words such as e-mail together")
+	numbers := flag.Bool("numbers", false, "keep numbers with separators such as 1,000.50 together")
+	normalize := flag.String("normalize", string(words.NormNone), "Unicode normalization before counting: none, nfc or nfkc")
+	fold := flag.Bool("fold", false, "use full Unicode case folding instead of lower-casing (ß matches ss)")
+	cjkMode := flag.String("cjk", string(words.CJKBigram), "how Chinese, Japanese and Korean text is split into words: none, bigram or dict")
+	dictPath := flag.String("dict", "", "word list for -cjk dict, one word per line (implies -cjk dict)")
+	stem := flag.Bool("stem", false, "group words by their English (Porter) stem and list the forms behind each")
+	compare := flag.String("compare", "", "comma-separated reference files, directories or globs to compare the input against")
+	significance := flag.Float64("p", 0.05, "significance level for -compare: 0.05, 0.01, 0.001 or 0.0001")
+	showStats := flag.Bool("stats", false, "print text statistics and readability scores before the counts")
+	kwic := flag.String("kwic", "", "list every occurrence of this word or phrase in context instead of counting")
+	context := flag.Int("context", 5, "words of context on each side for -kwic")
+	kwicSort := flag.String("kwic-sort", string(words.ByPosition), "order of the -kwic lines: position, left or right context")
+	collocations := flag.Bool("collocations", false, "list pairs of neighbouring words that occur together more than chance")
+	collocationScore := flag.String("score", string(words.ByPMI), "score -collocations are ranked by: pmi or t (t-score)")
+	minWord := flag.Int("min-word", 1, "for -collocations, leave out pairs whose first word starts, or second word ends, fewer pairs than this")
+	tfidf := flag.Bool("tfidf", false, "treat each file as a document and list its most distinctive terms by TF-IDF")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: wordcount [flags] [file|dir|glob|-]...")
+		fmt.Fprintln(flag.CommandLine.Output(), "Counts words in the given files, directories (recursively) and globs, or standard input.")
+		fmt.Fprintln(flag.CommandLine.Output(), "With -compare, lists the words used significantly more or less than in the reference.")
+		fmt.Fprintln(flag.CommandLine.Output(), "With -tfidf, lists the most distinctive terms of each file.")
+		fmt.Fprintln(flag.CommandLine.Output(), "With -kwic, lists each occurrence of a word with the words around it.")
+		fmt.Fprintln(flag.CommandLine.Output(), "With -collocations, lists word pairs scored by PMI or t-score.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	order, err := words.ParseSortOrder(*sortBy)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if *ngram < 1 {
+		fmt.Println("Error: -ngram must be at least 1")
+		os.Exit(1)
+	}
+	if modes := countTrue(*tfidf, *compare != "", *kwic != "", *collocations); modes > 1 {
+		fmt.Println("Error: use only one of -tfidf, -compare, -kwic and -collocations")
+		os.Exit(1)
+	} else if modes > 0 && *showStats {
+		fmt.Println("Error: -stats only goes with plain counting, not -tfidf, -compare, -kwic or -collocations")
+		os.Exit(1)
+	}
+	if *collocations && *ngram != 1 {
+		fmt.Println("Error: -collocations always counts pairs of words, leave out -ngram")
+		os.Exit(1)
+	}
+	scoreOrder, err := words.ParseCollocationOrder(*collocationScore)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	contextOrder, err := words.ParseContextOrder(*kwicSort)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if *context < 0 {
+		fmt.Println("Error: -context must not be negative")
+		os.Exit(1)
+	}
+	if *top < 0 {
+		fmt.Println("Error: -top must not be negative")
+		os.Exit(1)
+	}
+	form, err := words.ParseNormalization(*normalize)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	cjk, err := words.ParseCJKMode(*cjkMode)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	var dict *words.Dictionary
+	if *dictPath != "" {
+		if dict, err = words.LoadDictionary(*dictPath); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		cjk = words.CJKDict
+	} else if cjk == words.CJKDict {
+		fmt.Println("Error: -cjk dict needs a word list given with -dict")
+		os.Exit(1)
+	}
+	tokenizer := words.Rules{Apostrophes: *apostrophes, Hyphens: *hyphens, Numbers: *numbers, Form: form, Fold: *fold, CJK: cjk, Dict: dict}
+
+	stop, err := loadStopWords(tokenizer, *languages, *stopFiles)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	engine := words.NewEngine(*workers, *chunkKiB*1024)
+	engine.N = *ngram
+	engine.Tokenizer = tokenizer
+
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"-"}
+		if interactive() {
+			fmt.Println("Please enter the text you want to count (Ctrl-D to finish):")
+		}
+	}
+
+	if *kwic != "" {
+		if err := printConcordance(engine, args, *kwic, *context, contextOrder, *stem); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	rankOpts := words.RankOptions{Top: *top, MinCount: *minCount, Order: order}
+	if *collocations {
+		engine.N = 2
+		pairs, err := countPaths(engine, args)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		if *stem {
+			pairs = words.GroupByStem(pairs, words.PorterStemmer{}).Counts
+		}
+		opts := words.CollocationOptions{MinCount: *minCount, MinWordCount: *minWord, Stop: stop, Order: scoreOrder}
+		printCollocations(pairs, opts, *top)
+		return
+	}
+	if *tfidf {
+		if err := printTFIDF(engine, args, stop, *stem, rankOpts); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *showStats {
+		paths, err := words.ExpandPaths(args)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		freq, stats, err := engine.CountFilesWithStats(paths)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		printStats(stats)
+		fmt.Println()
+		freq.Remove(stop)
+		printCounts(freq, *stem, rankOpts)
+		return
+	}
+
+	freq, err := countPaths(engine, args)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	freq.Remove(stop)
+
+	if *compare == "" {
+		printCounts(freq, *stem, rankOpts)
+		return
+	}
+	if *stem {
+		freq = words.GroupByStem(freq, words.PorterStemmer{}).Counts
+	}
+	critical, err := words.CriticalValue(*significance)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	reference, err := countPaths(engine, strings.Split(*compare, ","))
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	reference.Remove(stop)
+	if *stem {
+		reference = words.GroupByStem(reference, words.PorterStemmer{}).Counts
+	}
+	printKeyness(freq, reference, critical, rankOpts)
+}
+
+// countTrue returns how many of flags are set.
+func countTrue(flags ...bool) int {
+	n := 0
+	for _, set := range flags {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// countPaths counts the words of file, directory and glob arguments.
+func countPaths(engine *words.Engine, args []string) (words.Counts, error) {
+	paths, err := words.ExpandPaths(args)
+	if err != nil {
+		return nil, err
+	}
+	return engine.CountFiles(paths)
+}
+
+// printCounts lists the ranked counts. Stemmed counts also list the surface
+// forms behind each stem.
+func printCounts(freq words.Counts, stem bool, opts words.RankOptions) {
+	var groups words.StemGroups
+	if stem {
+		groups = words.GroupByStem(freq, words.PorterStemmer{})
+		freq = groups.Counts
+	}
+	for _, entry := range words.Rank(freq, opts) {
+		if !stem {
+			fmt.Printf("%s: %d\n", entry.Word, entry.Count)
+			continue
+		}
+		forms := groups.SurfaceForms(entry.Word)
+		listed := make([]string, len(forms))
+		for i, form := range forms {
+			listed[i] = fmt.Sprintf("%s %d", form.Word, form.Count)
+		}
+		fmt.Printf("%s: %d (%s)\n", entry.Word, entry.Count, strings.Join(listed, ", "))
+	}
+}
+
+// printStats writes the text statistics and readability scores as a table.
+func printStats(stats words.Stats) {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "Characters\t%d\n", stats.Characters)
+	fmt.Fprintf(table, "Bytes\t%d\n", stats.Bytes)
+	fmt.Fprintf(table, "Lines\t%d\n", stats.Lines)
+	fmt.Fprintf(table, "Words\t%d\n", stats.Words)
+	fmt.Fprintf(table, "Sentences\t%d\n", stats.Sentences)
+	fmt.Fprintf(table, "Paragraphs\t%d\n", stats.Paragraphs)
+	fmt.Fprintf(table, "Words per sentence\t%.2f\n", stats.AverageSentenceLength())
+	fmt.Fprintf(table, "Syllables\t%d\n", stats.Syllables)
+	fmt.Fprintf(table, "Syllables per word\t%.2f\n", stats.SyllablesPerWord())
+	fmt.Fprintf(table, "Flesch reading ease\t%.1f\n", stats.FleschReadingEase())
+	fmt.Fprintf(table, "Flesch-Kincaid grade\t%.1f\n", stats.FleschKincaidGrade())
+	fmt.Fprintf(table, "Distinct words\t%d\n", stats.Types)
+	fmt.Fprintf(table, "Type-token ratio\t%.4f\n", stats.TypeTokenRatio())
+	fmt.Fprintf(table, "Hapax legomena\t%d\n", stats.Hapaxes)
+	table.Flush()
+}
+
+// printKeyness lists the words significantly over- and under-represented in
+// the target compared with the reference, most distinctive first. Top
+// limits each list and MinCount applies to both corpora together.
+func printKeyness(target, reference words.Counts, critical float64, opts words.RankOptions) {
+	fmt.Printf("Target: %d words, reference: %d words, log-likelihood >= %.2f\n", target.Total(), reference.Total(), critical)
+
+	var over, under []words.KeyWord
+	for _, k := range words.Keyness(target, reference) {
+		if k.LogLikelihood < critical || k.Target+k.Reference < opts.MinCount {
+			continue
+		}
+		if k.Overused() {
+			over = append(over, k)
+		} else {
+			under = append(under, k)
+		}
+	}
+	printKeyWords("Over-represented in the target", over, opts.Top)
+	printKeyWords("Over-represented in the reference", under, opts.Top)
+}
+
+// printKeyWords writes one keyness table.
+func printKeyWords(title string, keywords []words.KeyWord, top int) {
+	if top > 0 && len(keywords) > top {
+		keywords = keywords[:top]
+	}
+	fmt.Printf("\n%s: %d\n", title, len(keywords))
+	if len(keywords) == 0 {
+		return
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "  Word\tTarget\tReference\tTarget/M\tReference/M\tLL\tChi2\tLog ratio")
+	for _, k := range keywords {
+		fmt.Fprintf(table, "  %s\t%d\t%d\t%.1f\t%.1f\t%.2f\t%.2f\t%.2f\n",
+			k.Word, k.Target, k.Reference, k.TargetPM, k.ReferencePM, k.LogLikelihood, k.ChiSquare, k.LogRatio)
+	}
+	table.Flush()
+}
+
+// printCollocations lists the top scoring word pairs.
+func printCollocations(pairs words.Counts, opts words.CollocationOptions, top int) {
+	found := words.Collocations(pairs, opts)
+	fmt.Printf("Word pairs: %d, collocations: %d\n", pairs.Total(), len(found))
+	if top > 0 && len(found) > top {
+		found = found[:top]
+	}
+	if len(found) == 0 {
+		return
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "  Pair\tCount\tFirst\tSecond\tPMI\tt-score")
+	for _, c := range found {
+		fmt.Fprintf(table, "  %s\t%d\t%d\t%d\t%.2f\t%.2f\n", c.Pair, c.Count, c.FirstCount, c.SecondCount, c.PMI, c.TScore)
+	}
+	table.Flush()
+}
+
+// printTFIDF counts each file as a document of one collection and lists
+// every document's terms by TF-IDF. Top limits the terms per document and
+// MinCount drops terms seen fewer times in the document.
+func printTFIDF(engine *words.Engine, args []string, stop words.StopWords, stem bool, opts words.RankOptions) error {
+	paths, err := words.ExpandPaths(args)
+	if err != nil {
+		return err
+	}
+	counts, err := engine.CountEach(paths)
+	if err != nil {
+		return err
+	}
+	docs := make([]words.Document, len(paths))
+	for i, path := range paths {
+		counts[i].Remove(stop)
+		if stem {
+			counts[i] = words.GroupByStem(counts[i], words.PorterStemmer{}).Counts
+		}
+		docs[i] = words.Document{Name: path, Counts: counts[i]}
+	}
+
+	collection := words.NewCollection(docs)
+	for i, doc := range docs {
+		scores := collection.Scores(i, opts.MinCount)
+		if opts.Top > 0 && len(scores) > opts.Top {
+			scores = scores[:opts.Top]
+		}
+		fmt.Printf("\n%s (%d words)\n", doc.Name, doc.Counts.Total())
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "  Term\tCount\tTF\tDF\tTF-IDF")
+		for _, score := range scores {
+			fmt.Fprintf(table, "  %s\t%d\t%.4f\t%d\t%.4f\n", score.Term, score.Count, score.TF, score.DF, score.TFIDF)
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printConcordance lists every occurrence of query in the inputs with width
+// words of context on each side, the left context right-aligned so the
+// matches line up in one column.
+func printConcordance(engine *words.Engine, args []string, query string, width int, order words.ContextOrder, stem bool) error {
+	var want []string
+	words.EachWord(engine.Tokenizer, query, func(word string) { want = append(want, word) })
+	if len(want) == 0 {
+		return fmt.Errorf("-kwic %q contains no words", query)
+	}
+	var stemmer words.Stemmer
+	if stem {
+		stemmer = words.PorterStemmer{}
+	}
+
+	paths, err := words.ExpandPaths(args)
+	if err != nil {
+		return err
+	}
+	occurrences, err := engine.Concordance(paths, want, width, stemmer)
+	if err != nil {
+		return err
+	}
+	words.SortOccurrences(occurrences, order)
+
+	fmt.Printf("%s: %d occurrences\n", strings.Join(want, " "), len(occurrences))
+	locations := make([]string, len(occurrences))
+	lefts := make([]string, len(occurrences))
+	locationWidth, leftWidth := 0, 0
+	for i, o := range occurrences {
+		file := o.File
+		if file == "-" {
+			file = "stdin"
+		}
+		locations[i] = fmt.Sprintf("%s:%d", file, o.Line)
+		lefts[i] = joinText(o.Left)
+		locationWidth = max(locationWidth, utf8.RuneCountInString(locations[i]))
+		leftWidth = max(leftWidth, utf8.RuneCountInString(lefts[i]))
+	}
+	for i, o := range occurrences {
+		fmt.Printf("%-*s  %*s  %s  %s\n", locationWidth, locations[i], leftWidth, lefts[i], joinText(o.Match), joinText(o.Right))
+	}
+	return nil
+}
+
+// joinText joins tokens as they were written, separated by spaces.
+func joinText(tokens []words.Token) string {
+	written := make([]string, len(tokens))
+	for i, tok := range tokens {
+		written[i] = tok.Text
+	}
+	return strings.Join(written, " ")
+}
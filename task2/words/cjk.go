@@ -0,0 +1,132 @@
+package words
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CJKMode is how runs of Chinese, Japanese and Korean characters, which are
+// not separated by spaces, are split into words.
+type CJKMode string
+
+const (
+	// CJKNone keeps a run of CJK characters as one word.
+	CJKNone CJKMode = "none"
+	// CJKBigram counts every pair of neighbouring characters, the usual
+	// fallback when no word list is available. A lone character is counted
+	// by itself.
+	CJKBigram CJKMode = "bigram"
+	// CJKDict splits runs into the longest words of a Dictionary, counting
+	// characters it does not know one by one.
+	CJKDict CJKMode = "dict"
+)
+
+// ParseCJKMode validates a CJK segmentation mode name.
+func ParseCJKMode(name string) (CJKMode, error) {
+	switch CJKMode(name) {
+	case CJKNone, CJKBigram, CJKDict:
+		return CJKMode(name), nil
+	default:
+		return "", fmt.Errorf("unknown CJK segmentation %q, use none, bigram or dict", name)
+	}
+}
+
+// isCJK reports whether r is written in Han, Hiragana, Katakana or Hangul,
+// including the katakana prolonged sound mark.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) || r == 'ー'
+}
+
+// Dictionary is a word list for longest-match segmentation of CJK text.
+type Dictionary struct {
+	words  map[string]bool
+	maxLen int // longest word, in runes
+}
+
+// NewDictionary creates a dictionary of the given words.
+func NewDictionary(list []string) *Dictionary {
+	d := &Dictionary{words: make(map[string]bool, len(list))}
+	for _, word := range list {
+		d.Add(word)
+	}
+	return d
+}
+
+// Add puts a word into the dictionary.
+func (d *Dictionary) Add(word string) {
+	if word == "" {
+		return
+	}
+	d.words[word] = true
+	if n := utf8.RuneCountInString(word); n > d.maxLen {
+		d.maxLen = n
+	}
+}
+
+// LoadDictionary reads a word list with one word per line. Only the first
+// field of a line is used, so frequency lists such as "北京 3000 ns" work
+// too. Lines starting with # are comments.
+func LoadDictionary(path string) (*Dictionary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	d := NewDictionary(nil)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && !strings.HasPrefix(fields[0], "#") {
+			d.Add(fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// segment splits a run of CJK characters by mode, calling fn with each
+// part and its byte offset in run.
+func segment(run string, mode CJKMode, dict *Dictionary, fn func(part string, offset int)) {
+	chars := []rune(run)
+	switch {
+	case mode == CJKBigram && len(chars) > 1:
+		offset := 0
+		for i := 0; i+1 < len(chars); i++ {
+			fn(string(chars[i:i+2]), offset)
+			offset += utf8.RuneLen(chars[i])
+		}
+	case mode == CJKDict && dict != nil:
+		offset := 0
+		for _, part := range dict.longestMatch(chars) {
+			fn(part, offset)
+			offset += len(part)
+		}
+	default:
+		fn(run, 0)
+	}
+}
+
+// longestMatch splits chars into dictionary words from left to right,
+// always taking the longest word that fits.
+func (d *Dictionary) longestMatch(chars []rune) []string {
+	var segments []string
+	for i := 0; i < len(chars); {
+		size := 1
+		for n := min(d.maxLen, len(chars)-i); n > 1; n-- {
+			if d.words[string(chars[i:i+n])] {
+				size = n
+				break
+			}
+		}
+		segments = append(segments, string(chars[i:i+size]))
+		i += size
+	}
+	return segments
+}
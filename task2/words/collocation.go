@@ -0,0 +1,109 @@
+package words
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// CollocationOrder is the score collocations are ranked by.
+type CollocationOrder string
+
+const (
+	// ByPMI ranks by pointwise mutual information, which favours pairs whose
+	// words rarely occur apart, such as names and idioms, but also rare
+	// pairs seen once or twice.
+	ByPMI CollocationOrder = "pmi"
+	// ByTScore ranks by t-score, which favours frequent pairs that are
+	// reliably more common than chance.
+	ByTScore CollocationOrder = "t"
+)
+
+// ParseCollocationOrder validates a collocation score name.
+func ParseCollocationOrder(name string) (CollocationOrder, error) {
+	switch CollocationOrder(name) {
+	case ByPMI, ByTScore:
+		return CollocationOrder(name), nil
+	default:
+		return "", fmt.Errorf("unknown collocation score %q, use pmi or t", name)
+	}
+}
+
+// Collocation is a pair of neighbouring words and how much more often they
+// occur together than chance would have it.
+type Collocation struct {
+	Pair        string // the two words joined by a space
+	Count       int    // occurrences of the pair
+	FirstCount  int    // pairs starting with the first word
+	SecondCount int    // pairs ending with the second word
+	PMI         float64
+	TScore      float64
+}
+
+// CollocationOptions selects and orders collocations.
+type CollocationOptions struct {
+	MinCount     int       // leave out pairs seen fewer times
+	MinWordCount int       // leave out pairs whose first word starts, or second word ends, fewer pairs
+	Stop         StopWords // leave out pairs with a stop word
+	Order        CollocationOrder
+}
+
+// Collocations scores the word pairs of pairs, the counts of neighbouring
+// words within sentences as the engine counts them with N of 2, the
+// highest score first. The expected count of a pair is f1 * f2 / N, where
+// f1 is how many of the N pairs start with its first word and f2 how many
+// end with its second; PMI is log2 of the observed over the expected count
+// and the t-score is (observed - expected) / sqrt(observed). Pairs seen no
+// more often than expected are not collocations and are left out.
+func Collocations(pairs Counts, opts CollocationOptions) []Collocation {
+	first, second := make(Counts), make(Counts)
+	total := 0
+	for pair, n := range pairs {
+		w1, w2, ok := strings.Cut(pair, " ")
+		if !ok || strings.Contains(w2, " ") {
+			continue
+		}
+		first[w1] += n
+		second[w2] += n
+		total += n
+	}
+
+	var found []Collocation
+	for pair, n := range pairs {
+		w1, w2, ok := strings.Cut(pair, " ")
+		if !ok || strings.Contains(w2, " ") || n < opts.MinCount {
+			continue
+		}
+		if first[w1] < opts.MinWordCount || second[w2] < opts.MinWordCount || opts.Stop[w1] || opts.Stop[w2] {
+			continue
+		}
+		expected := float64(first[w1]) * float64(second[w2]) / float64(total)
+		if float64(n) <= expected {
+			continue
+		}
+		found = append(found, Collocation{
+			Pair:        pair,
+			Count:       n,
+			FirstCount:  first[w1],
+			SecondCount: second[w2],
+			PMI:         math.Log2(float64(n) / expected),
+			TScore:      (float64(n) - expected) / math.Sqrt(float64(n)),
+		})
+	}
+
+	score := func(c Collocation) float64 { return c.PMI }
+	if opts.Order == ByTScore {
+		score = func(c Collocation) float64 { return c.TScore }
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if a, b := score(found[i]), score(found[j]); a != b {
+			return a > b
+		}
+		if found[i].Count != found[j].Count {
+			return found[i].Count > found[j].Count
+		}
+		return found[i].Pair < found[j].Pair
+	})
+	return found
+}
@@ -0,0 +1,72 @@
+// Package words counts word frequencies in text of any size.
+package words
+
+import "strings"
+
+// Counts maps each word, or n-gram of words joined by spaces, to the number
+// of times it was seen.
+type Counts map[string]int
+
+// WordCount is one entry of a sorted frequency list.
+type WordCount struct {
+	Word  string
+	Count int
+}
+
+// EachWord calls fn with every word t finds in text.
+func EachWord(t Tokenizer, text string, fn func(word string)) {
+	t.Sentences(text, func(sentence []string) {
+		for _, word := range sentence {
+			fn(word)
+		}
+	})
+}
+
+// EachNGram calls fn with every run of n consecutive words within a
+// sentence of text, joined by single spaces. With n of 1 these are the
+// words themselves.
+func EachNGram(t Tokenizer, text string, n int, fn func(gram string)) {
+	eachNGram(t, text, n, 0, fn)
+}
+
+// eachNGram is EachNGram where the first skip words of text are only
+// context: n-grams made of them alone are left out.
+func eachNGram(t Tokenizer, text string, n, skip int, fn func(gram string)) {
+	seen := 0 // words of the sentences before
+	t.Sentences(text, func(sentence []string) {
+		defer func() { seen += len(sentence) }()
+		for i := 0; i+n <= len(sentence); i++ {
+			if seen+i+n <= skip {
+				continue
+			}
+			if n == 1 {
+				fn(sentence[i])
+				continue
+			}
+			fn(strings.Join(sentence[i:i+n], " "))
+		}
+	})
+}
+
+// Count returns the frequencies of the words t finds in text.
+func Count(t Tokenizer, text string) Counts {
+	count := make(Counts)
+	EachWord(t, text, func(word string) { count[word]++ })
+	return count
+}
+
+// Merge adds the counts of other to c.
+func (c Counts) Merge(other Counts) {
+	for word, n := range other {
+		c[word] += n
+	}
+}
+
+// Total returns the number of words counted.
+func (c Counts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
@@ -0,0 +1,116 @@
+package words
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// KeyWord compares how often a word is used in a target and a reference
+// corpus.
+type KeyWord struct {
+	Word          string
+	Target        int     // occurrences in the target
+	Reference     int     // occurrences in the reference
+	TargetPM      float64 // occurrences per million words of the target
+	ReferencePM   float64 // occurrences per million words of the reference
+	LogLikelihood float64
+	ChiSquare     float64
+	LogRatio      float64 // log2 of the relative frequencies, the effect size
+}
+
+// Overused reports whether the word is relatively more frequent in the
+// target than in the reference.
+func (k KeyWord) Overused() bool {
+	return k.TargetPM > k.ReferencePM
+}
+
+// criticalValues are the log-likelihood and chi-square values a keyword
+// must reach for a significance level, with one degree of freedom.
+var criticalValues = map[float64]float64{
+	0.05:   3.84,
+	0.01:   6.63,
+	0.001:  10.83,
+	0.0001: 15.13,
+}
+
+// CriticalValue returns the statistic a keyword must reach to be
+// significant at level p: 0.05, 0.01, 0.001 or 0.0001.
+func CriticalValue(p float64) (float64, error) {
+	critical, known := criticalValues[p]
+	if !known {
+		return 0, fmt.Errorf("unsupported significance level %g, use 0.05, 0.01, 0.001 or 0.0001", p)
+	}
+	return critical, nil
+}
+
+// Keyness scores every word of either corpus, the most distinctive
+// (highest log-likelihood) first. Both corpora must contain words.
+func Keyness(target, reference Counts) []KeyWord {
+	c, d := float64(target.Total()), float64(reference.Total())
+	if c == 0 || d == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(target)+len(reference))
+	var keywords []KeyWord
+	score := func(word string) {
+		if seen[word] {
+			return
+		}
+		seen[word] = true
+		a, b := float64(target[word]), float64(reference[word])
+		keywords = append(keywords, KeyWord{
+			Word:          word,
+			Target:        target[word],
+			Reference:     reference[word],
+			TargetPM:      a / c * 1e6,
+			ReferencePM:   b / d * 1e6,
+			LogLikelihood: logLikelihood(a, b, c, d),
+			ChiSquare:     chiSquare(a, b, c, d),
+			LogRatio:      math.Log2(((a + 0.5) / c) / ((b + 0.5) / d)),
+		})
+	}
+	for word := range target {
+		score(word)
+	}
+	for word := range reference {
+		score(word)
+	}
+
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].LogLikelihood != keywords[j].LogLikelihood {
+			return keywords[i].LogLikelihood > keywords[j].LogLikelihood
+		}
+		return keywords[i].Word < keywords[j].Word
+	})
+	return keywords
+}
+
+// logLikelihood is Dunning's G² for a word seen a times in c words and b
+// times in d words.
+func logLikelihood(a, b, c, d float64) float64 {
+	e1 := c * (a + b) / (c + d)
+	e2 := d * (a + b) / (c + d)
+	var g float64
+	if a > 0 {
+		g += a * math.Log(a/e1)
+	}
+	if b > 0 {
+		g += b * math.Log(b/e2)
+	}
+	return 2 * g
+}
+
+// chiSquare is Pearson's chi-square on the 2x2 table of the word against
+// every other word in each corpus.
+func chiSquare(a, b, c, d float64) float64 {
+	others1, others2 := c-a, d-b
+	n := c + d
+	denominator := (a + b) * (others1 + others2) * c * d
+	if denominator == 0 {
+		return 0
+	}
+	diff := a*others2 - b*others1
+	return n * diff * diff / denominator
+}
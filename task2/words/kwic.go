@@ -0,0 +1,163 @@
+package words
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// ContextOrder is how the lines of a concordance are listed.
+type ContextOrder string
+
+const (
+	// ByPosition lists occurrences in the order they appear.
+	ByPosition ContextOrder = "position"
+	// ByLeft sorts by the words before the match, nearest first, which
+	// groups what the word follows.
+	ByLeft ContextOrder = "left"
+	// ByRight sorts by the words after the match, which groups what
+	// follows the word.
+	ByRight ContextOrder = "right"
+)
+
+// ParseContextOrder validates a concordance order name.
+func ParseContextOrder(name string) (ContextOrder, error) {
+	switch ContextOrder(name) {
+	case ByPosition, ByLeft, ByRight:
+		return ContextOrder(name), nil
+	default:
+		return "", fmt.Errorf("unknown concordance order %q, use position, left or right", name)
+	}
+}
+
+// Occurrence is one line of a keyword-in-context (KWIC) concordance: a
+// match of the query with the words around it.
+type Occurrence struct {
+	File  string
+	Line  int // line of the first word of the match, from 1
+	Left  []Token
+	Match []Token
+	Right []Token
+}
+
+// Concordance finds every occurrence of query, a word or phrase of words as
+// the tokenizer splits them, in the files of paths and returns it with up
+// to width words of context on each side, in order of appearance. Context
+// crosses lines and sentences but not files. With a stemmer, words match
+// when their stems do. Files are streamed like CountFiles does, keeping only
+// the last words and the occurrences still waiting for their right context.
+func (e *Engine) Concordance(paths []string, query []string, width int, s Stemmer) ([]Occurrence, error) {
+	if len(query) == 0 {
+		return nil, nil
+	}
+	key := func(word string) string {
+		if s == nil {
+			return word
+		}
+		return s.Stem(word)
+	}
+	want := make([]string, len(query))
+	for i, word := range query {
+		want[i] = key(word)
+	}
+
+	var found []Occurrence
+	for _, path := range paths {
+		var recent []Token // the last width+len(query) tokens
+		var waiting []int  // found entries still missing right context
+		lines := 0
+		err := e.eachChunk(path, func(text []byte) {
+			e.Tokenizer.Tokens(string(text), func(tok Token) {
+				tok.Line += lines
+				for _, i := range waiting {
+					found[i].Right = append(found[i].Right, tok)
+				}
+				if len(waiting) > 0 && len(found[waiting[0]].Right) == width {
+					waiting = waiting[1:]
+				}
+
+				recent = append(recent, tok)
+				if len(recent) > width+len(want) {
+					recent = recent[1:]
+				}
+				if !matches(recent, want, key) {
+					return
+				}
+				start := len(recent) - len(want)
+				found = append(found, Occurrence{
+					File:  path,
+					Line:  recent[start].Line,
+					Left:  append([]Token(nil), recent[max(0, start-width):start]...),
+					Match: append([]Token(nil), recent[start:]...),
+				})
+				if width > 0 {
+					waiting = append(waiting, len(found)-1)
+				}
+			})
+			lines += bytes.Count(text, []byte("\n"))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return found, nil
+}
+
+// matches reports whether the last tokens of recent are the wanted words.
+func matches(recent []Token, want []string, key func(word string) string) bool {
+	if len(recent) < len(want) {
+		return false
+	}
+	tail := recent[len(recent)-len(want):]
+	for i, word := range want {
+		if key(tail[i].Word) != word {
+			return false
+		}
+	}
+	return true
+}
+
+// eachChunk calls fn with the chunks of one file in order.
+func (e *Engine) eachChunk(path string, fn func(text []byte)) error {
+	chunks := make(chan chunk, e.Workers)
+	feedErr := make(chan error, 1)
+	go func() {
+		feedErr <- e.splitFile(path, chunks)
+		close(chunks)
+	}()
+	for c := range chunks {
+		fn(c.text[c.lead:])
+	}
+	return <-feedErr
+}
+
+// SortOccurrences orders a concordance. Occurrences with the same context
+// keep their order of appearance.
+func SortOccurrences(occurrences []Occurrence, order ContextOrder) {
+	switch order {
+	case ByLeft:
+		sort.SliceStable(occurrences, func(i, j int) bool {
+			return lessContext(occurrences[i].Left, occurrences[j].Left, true)
+		})
+	case ByRight:
+		sort.SliceStable(occurrences, func(i, j int) bool {
+			return lessContext(occurrences[i].Right, occurrences[j].Right, false)
+		})
+	}
+}
+
+// lessContext compares two contexts word by word, from the nearest word
+// outwards when reverse is set. A shorter context that matches as far as
+// it goes comes first.
+func lessContext(a, b []Token, reverse bool) bool {
+	for k := 0; k < len(a) && k < len(b); k++ {
+		x, y := a[k], b[k]
+		if reverse {
+			x, y = a[len(a)-1-k], b[len(b)-1-k]
+		}
+		if x.Word != y.Word {
+			return x.Word < y.Word
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,99 @@
+package words
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// SortOrder is how ranked words are listed.
+type SortOrder string
+
+const (
+	// ByFrequency lists the most frequent words first, ties alphabetically.
+	ByFrequency SortOrder = "freq"
+	// Alphabetical lists words in alphabetical order.
+	Alphabetical SortOrder = "alpha"
+)
+
+// ParseSortOrder validates a sort order name.
+func ParseSortOrder(name string) (SortOrder, error) {
+	switch SortOrder(name) {
+	case ByFrequency, Alphabetical:
+		return SortOrder(name), nil
+	default:
+		return "", fmt.Errorf("unknown sort order %q, use freq or alpha", name)
+	}
+}
+
+// RankOptions selects and orders the words of a count.
+type RankOptions struct {
+	Top      int // keep only the Top most frequent words; 0 keeps all
+	MinCount int // drop words seen fewer times than this
+	Order    SortOrder
+}
+
+// Rank lists the counts that pass MinCount, keeps the Top most frequent of
+// them and sorts those by Order.
+func Rank(counts Counts, opts RankOptions) []WordCount {
+	var ranked []WordCount
+	if opts.Top > 0 {
+		ranked = topK(counts, opts.Top, opts.MinCount)
+	} else {
+		ranked = make([]WordCount, 0, len(counts))
+		for word, n := range counts {
+			if n >= opts.MinCount {
+				ranked = append(ranked, WordCount{Word: word, Count: n})
+			}
+		}
+	}
+
+	if opts.Order == Alphabetical {
+		sort.Slice(ranked, func(i, j int) bool { return ranked[i].Word < ranked[j].Word })
+	} else {
+		sort.Slice(ranked, func(i, j int) bool { return moreFrequent(ranked[i], ranked[j]) })
+	}
+	return ranked
+}
+
+// moreFrequent orders a before b when it was seen more often, breaking ties
+// alphabetically.
+func moreFrequent(a, b WordCount) bool {
+	if a.Count != b.Count {
+		return a.Count > b.Count
+	}
+	return a.Word < b.Word
+}
+
+// topK keeps the k most frequent words with a min-heap of size k, so large
+// vocabularies are never sorted in full.
+func topK(counts Counts, k, minCount int) []WordCount {
+	h := &wordHeap{}
+	for word, n := range counts {
+		if n < minCount {
+			continue
+		}
+		entry := WordCount{Word: word, Count: n}
+		if h.Len() < k {
+			heap.Push(h, entry)
+		} else if moreFrequent(entry, (*h)[0]) {
+			(*h)[0] = entry
+			heap.Fix(h, 0)
+		}
+	}
+	return *h
+}
+
+// wordHeap is a min-heap with the least frequent word on top.
+type wordHeap []WordCount
+
+func (h wordHeap) Len() int           { return len(h) }
+func (h wordHeap) Less(i, j int) bool { return moreFrequent(h[j], h[i]) }
+func (h wordHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *wordHeap) Push(x any)        { *h = append(*h, x.(WordCount)) }
+func (h *wordHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,193 @@
+package words
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// Stats are wc-style and readability figures of a text, measured in the
+// same pass and with the same tokenizer as the word counts.
+type Stats struct {
+	Bytes      int
+	Characters int // runes, including spaces and punctuation
+	Lines      int // line breaks, as wc counts them
+	Words      int
+	Sentences  int
+	Paragraphs int // runs of text separated by blank lines
+	Syllables  int // estimated with Syllables
+	Types      int // distinct words
+	Hapaxes    int // words seen exactly once
+}
+
+// Add adds the figures of other to s. Types and Hapaxes depend on the
+// whole vocabulary and are left alone.
+func (s *Stats) Add(other Stats) {
+	s.Bytes += other.Bytes
+	s.Characters += other.Characters
+	s.Lines += other.Lines
+	s.Words += other.Words
+	s.Sentences += other.Sentences
+	s.Paragraphs += other.Paragraphs
+	s.Syllables += other.Syllables
+}
+
+// Vocabulary sets Types and Hapaxes from the counts of single words.
+func (s *Stats) Vocabulary(words Counts) {
+	s.Types, s.Hapaxes = len(words), 0
+	for _, n := range words {
+		if n == 1 {
+			s.Hapaxes++
+		}
+	}
+}
+
+// AverageSentenceLength is the mean number of words per sentence.
+func (s Stats) AverageSentenceLength() float64 {
+	return ratio(s.Words, s.Sentences)
+}
+
+// SyllablesPerWord is the mean number of syllables per word.
+func (s Stats) SyllablesPerWord() float64 {
+	return ratio(s.Syllables, s.Words)
+}
+
+// FleschReadingEase scores how easy English text is to read, from about 100
+// (very easy) down to 0 (very hard).
+func (s Stats) FleschReadingEase() float64 {
+	if s.Words == 0 || s.Sentences == 0 {
+		return 0
+	}
+	return 206.835 - 1.015*s.AverageSentenceLength() - 84.6*s.SyllablesPerWord()
+}
+
+// FleschKincaidGrade is the US school grade needed to understand English
+// text.
+func (s Stats) FleschKincaidGrade() float64 {
+	if s.Words == 0 || s.Sentences == 0 {
+		return 0
+	}
+	return 0.39*s.AverageSentenceLength() + 11.8*s.SyllablesPerWord() - 15.59
+}
+
+// TypeTokenRatio is the share of words that are distinct, a measure of
+// lexical variety that falls as texts get longer.
+func (s Stats) TypeTokenRatio() float64 {
+	return ratio(s.Types, s.Words)
+}
+
+// ratio divides a by b, or returns 0 when b is 0.
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
+
+// measure counts the n-grams of a chunk into counts like EachNGram and
+// adds the figures of the chunk to s, splitting it only once. The lead of
+// the chunk and a paragraph or sentence carried on from the chunk before
+// are not counted again. When n is above 1 the single words go into
+// vocabulary.
+func (s *Stats) measure(t Tokenizer, c chunk, n int, counts, vocabulary Counts) {
+	own := c.text[c.lead:]
+	s.Bytes += len(own)
+	s.Characters += utf8.RuneCount(own)
+	s.Lines += bytes.Count(own, []byte("\n"))
+
+	// A sentence or paragraph is new when it starts after the lead, unless
+	// it goes on from the chunk before.
+	isNew := func(start int, index int, mid bool) bool {
+		if c.skip > 0 {
+			return start >= c.skip
+		}
+		return !(mid && index == 0)
+	}
+	var sentence []string
+	sentenceIndex, sentenceStart, words := 0, 0, 0
+	flush := func() {
+		if len(sentence) > 0 && isNew(sentenceStart, sentenceIndex, c.midSentence) {
+			s.Sentences++
+		}
+		for i := 0; n > 1 && i+n <= len(sentence); i++ {
+			if sentenceStart+i+n > c.skip {
+				counts[strings.Join(sentence[i:i+n], " ")]++
+			}
+		}
+		sentence = sentence[:0]
+	}
+	first, lastSentence, lastParagraph := true, 0, 0
+	t.Tokens(string(c.text), func(tok Token) {
+		if (first || tok.Paragraph != lastParagraph) && isNew(words, tok.Paragraph, c.midParagraph) {
+			s.Paragraphs++
+		}
+		if !first && tok.Sentence != lastSentence {
+			flush()
+		}
+		if first || tok.Sentence != lastSentence {
+			sentenceStart = words
+		}
+		first, lastSentence, lastParagraph = false, tok.Sentence, tok.Paragraph
+		sentenceIndex = tok.Sentence
+		sentence = append(sentence, tok.Word)
+		words++
+		if words <= c.skip {
+			return
+		}
+
+		s.Words++
+		s.Syllables += Syllables(tok.Word)
+		if n == 1 {
+			counts[tok.Word]++
+		} else {
+			vocabulary[tok.Word]++
+		}
+	})
+	flush()
+}
+
+// Syllables estimates the syllables of an English word by counting groups
+// of vowels, leaving out a silent final "e" and the "e" of "-es" and "-ed"
+// where it is usually silent, as in "make", "makes" and "jumped". Every
+// word has at least one syllable, numbers included.
+func Syllables(word string) int {
+	n, vowel := 0, false
+	for _, r := range word {
+		if isVowel(r) && !vowel {
+			n++
+		}
+		vowel = isVowel(r)
+	}
+	if n > 1 && silentE(word) {
+		n--
+	}
+	return max(n, 1)
+}
+
+// isVowel reports whether r is a vowel letter, y included.
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiouyàáâäæèéêëìíîïòóôöœùúûüý", r)
+}
+
+// silentE reports whether word ends in an "e" that is usually not spoken: a
+// final "e" after a consonant other than in "-le" ("make" but not "table"),
+// likewise before a final "s" ("syllables"),
+// "-es" other than after a sibilant ("makes" but not "boxes") and "-ed"
+// other than after t or d ("jumped" but not "wanted").
+func silentE(word string) bool {
+	b := []rune(word)
+	consonantAt := func(i int) bool { return i >= 0 && !isVowel(b[i]) }
+	k := len(b) - 1
+	switch {
+	case b[k] == 'e' && consonantAt(k-1):
+		return !(b[k-1] == 'l' && consonantAt(k-2))
+	case b[k] == 's' && k >= 2 && b[k-1] == 'e' && consonantAt(k-2):
+		if b[k-2] == 'l' && consonantAt(k-3) {
+			return false
+		}
+		return !strings.ContainsRune("sxzch", b[k-2]) && !strings.HasSuffix(word, "ges")
+	case b[k] == 'd' && k >= 2 && b[k-1] == 'e' && consonantAt(k-2):
+		return b[k-2] != 't' && b[k-2] != 'd'
+	}
+	return false
+}
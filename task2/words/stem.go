@@ -0,0 +1,367 @@
+package words
+
+import (
+	"sort"
+	"strings"
+)
+
+// Stemmer reduces a word to its stem, so that inflected forms such as
+// "runs" and "running" group under "run".
+type Stemmer interface {
+	Stem(word string) string
+}
+
+// PorterStemmer is Martin Porter's 1980 stemming algorithm for English.
+// Words with anything other than the letters a to z are left as they are.
+type PorterStemmer struct{}
+
+func (PorterStemmer) Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	p := &porter{b: []byte(word), k: len(word) - 1}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.step2()
+		p.step3()
+		p.step4()
+		p.step5()
+	}
+	return string(p.b[:p.k+1])
+}
+
+// porter holds a word being stemmed: b[:k+1] is the current word and j marks
+// the end of the stem once a suffix has been matched.
+type porter struct {
+	b    []byte
+	k, j int
+}
+
+// cons reports whether b[i] is a consonant. y is a consonant unless it
+// follows one.
+func (p *porter) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+	return true
+}
+
+// m counts the vowel-consonant sequences in b[:j+1].
+func (p *porter) m() int {
+	n, i := 0, 0
+	for ; i <= p.j && p.cons(i); i++ {
+	}
+	for i <= p.j {
+		for ; i <= p.j && !p.cons(i); i++ {
+		}
+		if i > p.j {
+			break
+		}
+		n++
+		for ; i <= p.j && p.cons(i); i++ {
+		}
+	}
+	return n
+}
+
+// vowelInStem reports whether b[:j+1] contains a vowel.
+func (p *porter) vowelInStem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleC reports whether b[i-1:i+1] is a double consonant.
+func (p *porter) doubleC(i int) bool {
+	return i >= 1 && p.b[i] == p.b[i-1] && p.cons(i)
+}
+
+// cvc reports whether b[i-2:i+1] is consonant-vowel-consonant with the last
+// consonant not w, x or y, as in "hop" but not "snow".
+func (p *porter) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+	ch := p.b[i]
+	return ch != 'w' && ch != 'x' && ch != 'y'
+}
+
+// ends reports whether the word ends with s, setting j to the end of the
+// stem before it.
+func (p *porter) ends(s string) bool {
+	if len(s) > p.k+1 || string(p.b[p.k+1-len(s):p.k+1]) != s {
+		return false
+	}
+	p.j = p.k - len(s)
+	return true
+}
+
+// setTo replaces the suffix after j with s.
+func (p *porter) setTo(s string) {
+	p.b = append(p.b[:p.j+1], s...)
+	p.k = p.j + len(s)
+}
+
+// r replaces the suffix after j with s when the stem has a measure above 0.
+func (p *porter) r(s string) {
+	if p.m() > 0 {
+		p.setTo(s)
+	}
+}
+
+// step1ab removes plurals and -ed or -ing.
+func (p *porter) step1ab() {
+	if p.b[p.k] == 's' {
+		if p.ends("sses") {
+			p.k -= 2
+		} else if p.ends("ies") {
+			p.setTo("i")
+		} else if p.b[p.k-1] != 's' {
+			p.k--
+		}
+	}
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.k--
+		}
+	} else if (p.ends("ed") || p.ends("ing")) && p.vowelInStem() {
+		p.k = p.j
+		switch {
+		case p.ends("at"):
+			p.setTo("ate")
+		case p.ends("bl"):
+			p.setTo("ble")
+		case p.ends("iz"):
+			p.setTo("ize")
+		case p.doubleC(p.k):
+			p.k--
+			if ch := p.b[p.k]; ch == 'l' || ch == 's' || ch == 'z' {
+				p.k++
+			}
+		case p.m() == 1 && p.cvc(p.k):
+			p.setTo("e")
+		}
+	}
+}
+
+// step1c turns a final y into i when there is another vowel in the stem.
+func (p *porter) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// step2 maps double suffixes to single ones, e.g. -ization to -ize.
+func (p *porter) step2() {
+	switch p.b[p.k-1] {
+	case 'a':
+		if p.ends("ational") {
+			p.r("ate")
+		} else if p.ends("tional") {
+			p.r("tion")
+		}
+	case 'c':
+		if p.ends("enci") {
+			p.r("ence")
+		} else if p.ends("anci") {
+			p.r("ance")
+		}
+	case 'e':
+		if p.ends("izer") {
+			p.r("ize")
+		}
+	case 'l':
+		if p.ends("bli") {
+			p.r("ble")
+		} else if p.ends("alli") {
+			p.r("al")
+		} else if p.ends("entli") {
+			p.r("ent")
+		} else if p.ends("eli") {
+			p.r("e")
+		} else if p.ends("ousli") {
+			p.r("ous")
+		}
+	case 'o':
+		if p.ends("ization") {
+			p.r("ize")
+		} else if p.ends("ation") {
+			p.r("ate")
+		} else if p.ends("ator") {
+			p.r("ate")
+		}
+	case 's':
+		if p.ends("alism") {
+			p.r("al")
+		} else if p.ends("iveness") {
+			p.r("ive")
+		} else if p.ends("fulness") {
+			p.r("ful")
+		} else if p.ends("ousness") {
+			p.r("ous")
+		}
+	case 't':
+		if p.ends("aliti") {
+			p.r("al")
+		} else if p.ends("iviti") {
+			p.r("ive")
+		} else if p.ends("biliti") {
+			p.r("ble")
+		}
+	case 'g':
+		if p.ends("logi") {
+			p.r("log")
+		}
+	}
+}
+
+// step3 handles -ic-, -full, -ness and similar.
+func (p *porter) step3() {
+	switch p.b[p.k] {
+	case 'e':
+		if p.ends("icate") {
+			p.r("ic")
+		} else if p.ends("ative") {
+			p.r("")
+		} else if p.ends("alize") {
+			p.r("al")
+		}
+	case 'i':
+		if p.ends("iciti") {
+			p.r("ic")
+		}
+	case 'l':
+		if p.ends("ical") {
+			p.r("ic")
+		} else if p.ends("ful") {
+			p.r("")
+		}
+	case 's':
+		if p.ends("ness") {
+			p.r("")
+		}
+	}
+}
+
+// step4 removes -ant, -ence and the like when the stem is long enough.
+func (p *porter) step4() {
+	switch p.b[p.k-1] {
+	case 'a':
+		if !p.ends("al") {
+			return
+		}
+	case 'c':
+		if !p.ends("ance") && !p.ends("ence") {
+			return
+		}
+	case 'e':
+		if !p.ends("er") {
+			return
+		}
+	case 'i':
+		if !p.ends("ic") {
+			return
+		}
+	case 'l':
+		if !p.ends("able") && !p.ends("ible") {
+			return
+		}
+	case 'n':
+		if !p.ends("ant") && !p.ends("ement") && !p.ends("ment") && !p.ends("ent") {
+			return
+		}
+	case 'o':
+		if !(p.ends("ion") && p.j >= 0 && (p.b[p.j] == 's' || p.b[p.j] == 't')) && !p.ends("ou") {
+			return
+		}
+	case 's':
+		if !p.ends("ism") {
+			return
+		}
+	case 't':
+		if !p.ends("ate") && !p.ends("iti") {
+			return
+		}
+	case 'u':
+		if !p.ends("ous") {
+			return
+		}
+	case 'v':
+		if !p.ends("ive") {
+			return
+		}
+	case 'z':
+		if !p.ends("ize") {
+			return
+		}
+	default:
+		return
+	}
+	if p.m() > 1 {
+		p.k = p.j
+	}
+}
+
+// step5 removes a final -e and turns -ll into -l when the stem is long
+// enough.
+func (p *porter) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		if a := p.m(); a > 1 || a == 1 && !p.cvc(p.k-1) {
+			p.k--
+		}
+	}
+	if p.b[p.k] == 'l' && p.doubleC(p.k) && p.m() > 1 {
+		p.k--
+	}
+}
+
+// StemGroups is a word count merged by stem. Counts holds each stem's
+// total and Forms the counts of the surface forms behind it.
+type StemGroups struct {
+	Counts Counts
+	Forms  map[string]Counts
+}
+
+// GroupByStem merges the counts of words sharing a stem. Every word of an
+// n-gram is stemmed, so "running shoes" and "run shoe" group together.
+func GroupByStem(counts Counts, s Stemmer) StemGroups {
+	groups := StemGroups{Counts: make(Counts), Forms: make(map[string]Counts)}
+	for gram, n := range counts {
+		words := strings.Split(gram, " ")
+		for i, word := range words {
+			words[i] = s.Stem(word)
+		}
+		stem := strings.Join(words, " ")
+
+		groups.Counts[stem] += n
+		if groups.Forms[stem] == nil {
+			groups.Forms[stem] = make(Counts)
+		}
+		groups.Forms[stem][gram] += n
+	}
+	return groups
+}
+
+// SurfaceForms lists the forms grouped under stem, most frequent first.
+func (g StemGroups) SurfaceForms(stem string) []WordCount {
+	forms := make([]WordCount, 0, len(g.Forms[stem]))
+	for form, n := range g.Forms[stem] {
+		forms = append(forms, WordCount{Word: form, Count: n})
+	}
+	sort.Slice(forms, func(i, j int) bool { return moreFrequent(forms[i], forms[j]) })
+	return forms
+}
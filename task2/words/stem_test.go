@@ -0,0 +1,52 @@
+package words
+
+import "testing"
+
+// porterSamples are the examples of Porter's 1980 paper, "An algorithm for
+// suffix stripping", run through all five steps.
+var porterSamples = map[string]string{
+	// Step 1a and 1b.
+	"caresses": "caress", "ponies": "poni", "ties": "ti", "caress": "caress", "cats": "cat",
+	"feed": "feed", "agreed": "agre", "plastered": "plaster", "bled": "bled", "motoring": "motor",
+	"sing": "sing", "conflated": "conflat", "troubled": "troubl", "sized": "size", "hopping": "hop",
+	"tanned": "tan", "falling": "fall", "hissing": "hiss", "fizzed": "fizz", "failing": "fail",
+	"filing": "file",
+	// Step 1c.
+	"happy": "happi", "sky": "sky",
+	// Step 2.
+	"relational": "relat", "conditional": "condit", "rational": "ration", "valenci": "valenc",
+	"hesitanci": "hesit", "digitizer": "digit", "conformabli": "conform", "radicalli": "radic",
+	"differentli": "differ", "vileli": "vile", "analogousli": "analog", "vietnamization": "vietnam",
+	"predication": "predic", "operator": "oper", "feudalism": "feudal", "decisiveness": "decis",
+	"hopefulness": "hope", "callousness": "callous", "formaliti": "formal", "sensitiviti": "sensit",
+	"sensibiliti": "sensibl",
+	// Step 3.
+	"triplicate": "triplic", "formative": "form", "formalize": "formal", "electriciti": "electr",
+	"electrical": "electr", "hopeful": "hope", "goodness": "good",
+	// Step 4.
+	"revival": "reviv", "allowance": "allow", "inference": "infer", "airliner": "airlin",
+	"gyroscopic": "gyroscop", "adjustable": "adjust", "defensible": "defens", "irritant": "irrit",
+	"replacement": "replac", "adjustment": "adjust", "dependent": "depend", "adoption": "adopt",
+	"homologou": "homolog", "communism": "commun", "activate": "activ", "angulariti": "angular",
+	"homologous": "homolog", "effective": "effect", "bowdlerize": "bowdler",
+	// Step 5.
+	"probate": "probat", "rate": "rate", "cease": "ceas", "controll": "control", "roll": "roll",
+	// Several steps at once.
+	"generalizations": "gener", "oscillators": "oscil",
+}
+
+func TestPorterStemmer(t *testing.T) {
+	for word, want := range porterSamples {
+		if got := (PorterStemmer{}).Stem(word); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestPorterStemmerLeavesOtherWords(t *testing.T) {
+	for _, word := range []string{"a", "is", "naïve", "don't", "e-mail", "42", "北京"} {
+		if got := (PorterStemmer{}).Stem(word); got != word {
+			t.Errorf("Stem(%q) = %q, want it unchanged", word, got)
+		}
+	}
+}
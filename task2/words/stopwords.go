@@ -0,0 +1,114 @@
+package words
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// StopWords is a set of words left out of the ranking.
+type StopWords map[string]bool
+
+// builtinStopWords are the stop word lists shipped with the counter, keyed
+// by language.
+var builtinStopWords = map[string]string{
+	"english": `a about above after again against all am an and any are as at be
+		because been before being below between both but by can could did do does
+		doing down during each few for from further had has have having he her here
+		hers herself him himself his how i if in into is it its itself just me
+		more most my myself no nor not now of off on once only or other our ours
+		ourselves out over own same she should so some such than that the their
+		theirs them themselves then there these they this those through to too
+		under until up very was we were what when where which while who whom why
+		will with would you your yours yourself yourselves don't can't won't
+		isn't it's i'm`,
+	"french": `a au aux avec ce ces dans de des du elle en et eux il ils je la le
+		les leur lui ma mais me même mes moi mon ne nos notre nous on ou par pas
+		pour qu que qui sa se ses son sur ta te tes toi ton tu un une vos votre
+		vous c d j l à m n s t y été être avoir est sont était ont fait comme
+		plus si tout`,
+	"spanish": `a al algo como con de del donde el ella ellas ellos en entre era es
+		esa ese eso esta este esto fue ha hay la las le les lo los más me mi muy
+		nada ni no nos o os para pero por que se si sin sobre su sus también te
+		tu un una uno unos y ya yo`,
+	"german": `aber alle als also am an auch auf aus bei bin bis da das dass dem den
+		der des die doch du ein eine einem einen einer es für hat hatte ich ihr
+		im in ist ja kein mit nach nicht noch nur ob oder sie sich sind so über
+		um und uns von vor war wie wir wird zu zum zur`,
+}
+
+// Languages returns the names of the built-in stop word lists.
+func Languages() []string {
+	names := make([]string, 0, len(builtinStopWords))
+	for name := range builtinStopWords {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add puts the space-separated words of text into the set as t finds them.
+// Stop words must be split by the tokenizer that splits the counted text so
+// the two match. An entry t splits into several words, such as "don't"
+// without the apostrophes rule, is left out rather than making "don" and
+// "t" stop words.
+func (s StopWords) Add(t Tokenizer, text string) {
+	for _, entry := range strings.Fields(text) {
+		var found []string
+		EachWord(t, entry, func(word string) { found = append(found, word) })
+		if len(found) == 1 {
+			s[found[0]] = true
+		}
+	}
+}
+
+// AddLanguage adds the built-in list of a language.
+func (s StopWords) AddLanguage(t Tokenizer, language string) error {
+	list, known := builtinStopWords[strings.ToLower(strings.TrimSpace(language))]
+	if !known {
+		return fmt.Errorf("no stop words for %q, available: %s", language, strings.Join(Languages(), ", "))
+	}
+	s.Add(t, list)
+	return nil
+}
+
+// AddFile adds the words of a stop word file. Lines starting with # are
+// comments.
+func (s StopWords) AddFile(t Tokenizer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			s.Add(t, line)
+		}
+	}
+	return scanner.Err()
+}
+
+// Remove deletes the stop words from c. N-grams are deleted when every one
+// of their words is a stop word, so "of the" goes but "end of the" stays.
+func (c Counts) Remove(stop StopWords) {
+	if len(stop) == 0 {
+		return
+	}
+	for gram := range c {
+		allStop := true
+		for _, word := range strings.Split(gram, " ") {
+			if !stop[word] {
+				allStop = false
+				break
+			}
+		}
+		if allStop {
+			delete(c, gram)
+		}
+	}
+}
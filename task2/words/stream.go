@@ -0,0 +1,341 @@
+package words
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultChunkSize is how many bytes the engine reads at a time.
+const DefaultChunkSize = 64 * 1024
+
+// Engine counts words in streams of any size. Input is read in chunks that
+// end between words, the chunks are counted by a pool of goroutines and
+// their partial counts are merged at the end, so memory is bounded by the
+// chunks in flight plus the vocabulary. With N above 1 the engine counts
+// n-grams of N words instead of single words.
+type Engine struct {
+	Workers   int
+	ChunkSize int
+	N         int
+	Tokenizer Tokenizer
+}
+
+// NewEngine creates an engine with the given number of workers and chunk
+// size in bytes, counting single words with DefaultTokenizer. Values below
+// 1 fall back to one worker and DefaultChunkSize.
+func NewEngine(workers, chunkSize int) *Engine {
+	if workers < 1 {
+		workers = 1
+	}
+	if chunkSize < 1 {
+		chunkSize = DefaultChunkSize
+	}
+	return &Engine{Workers: workers, ChunkSize: chunkSize, N: 1, Tokenizer: DefaultTokenizer}
+}
+
+// CountReader counts every word read from r until EOF.
+func (e *Engine) CountReader(r io.Reader) (Counts, error) {
+	return e.count(func(chunks chan<- chunk) error {
+		return e.split(r, chunks)
+	}, nil)
+}
+
+// CountFiles counts the words of every file in paths, one after the other.
+// The path "-" reads standard input.
+func (e *Engine) CountFiles(paths []string) (Counts, error) {
+	return e.count(func(chunks chan<- chunk) error {
+		for _, path := range paths {
+			if err := e.splitFile(path, chunks); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil)
+}
+
+// CountFilesWithStats counts the words of paths like CountFiles and
+// measures the text in the same pass. The stats are taken before any stop
+// words are removed and are about single words even when counting n-grams.
+func (e *Engine) CountFilesWithStats(paths []string) (Counts, Stats, error) {
+	var stats Stats
+	counts, err := e.count(func(chunks chan<- chunk) error {
+		for _, path := range paths {
+			if err := e.splitFile(path, chunks); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, &stats)
+	return counts, stats, err
+}
+
+// CountEach counts every file in paths on its own, in order, for when each
+// file is a separate document.
+func (e *Engine) CountEach(paths []string) ([]Counts, error) {
+	all := make([]Counts, 0, len(paths))
+	for _, path := range paths {
+		counts, err := e.count(func(chunks chan<- chunk) error {
+			return e.splitFile(path, chunks)
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, counts)
+	}
+	return all, nil
+}
+
+// splitFile sends the chunks of one file.
+func (e *Engine) splitFile(path string, chunks chan<- chunk) error {
+	if path == "-" {
+		return e.split(os.Stdin, chunks)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := e.split(file, chunks); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// partial is what one worker counted.
+type partial struct {
+	counts     Counts
+	vocabulary Counts // single words, when measuring n-gram counts
+	stats      Stats
+}
+
+// count runs feed, which sends chunks of input, against the worker pool and
+// merges what the workers counted. When stats is not nil the workers also
+// measure the text into it.
+func (e *Engine) count(feed func(chunks chan<- chunk) error, stats *Stats) (Counts, error) {
+	chunks := make(chan chunk, e.Workers)
+	partials := make(chan partial, e.Workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < e.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := partial{counts: make(Counts), vocabulary: make(Counts)}
+			for c := range chunks {
+				if stats != nil {
+					p.stats.measure(e.Tokenizer, c, e.N, p.counts, p.vocabulary)
+					continue
+				}
+				eachNGram(e.Tokenizer, string(c.text), e.N, c.skip, func(gram string) { p.counts[gram]++ })
+			}
+			partials <- p
+		}()
+	}
+
+	feedErr := make(chan error, 1)
+	go func() {
+		feedErr <- feed(chunks)
+		close(chunks)
+	}()
+	go func() {
+		wg.Wait()
+		close(partials)
+	}()
+
+	total, vocabulary := make(Counts), make(Counts)
+	for p := range partials {
+		total.Merge(p.counts)
+		vocabulary.Merge(p.vocabulary)
+		if stats != nil {
+			stats.Add(p.stats)
+		}
+	}
+	if err := <-feedErr; err != nil {
+		return nil, err
+	}
+	if stats != nil {
+		if e.N == 1 {
+			vocabulary = total
+		}
+		stats.Vocabulary(vocabulary)
+	}
+	return total, nil
+}
+
+// chunk is a piece of input cut between words, knowing whether it goes on
+// with the paragraph and sentence the chunk before it ended in. Only a cut
+// after a blank line or sentence terminator starts a new sentence, so the
+// sentence counts of Stats do not depend on the chunk size. When counting
+// n-grams, a chunk going on with a sentence starts with the end of the
+// chunk before as a lead: its skip words are only context, so n-grams
+// across the cut are counted once, by this chunk.
+type chunk struct {
+	text         []byte
+	lead         int // bytes of text repeated from the chunk before
+	skip         int // words of the lead
+	midParagraph bool
+	midSentence  bool
+}
+
+// split reads r in chunks of about ChunkSize bytes and sends them cut after
+// the last paragraph, carrying the rest over to the next chunk, so
+// paragraphs and sentences are counted once. A chunk without a blank line
+// is cut after the last sentence, and one without a sentence end after the
+// last separator no tokenizer rule joins words with, such as whitespace or
+// "，", so the cut cannot split "don't" or "1,000". The next chunk then
+// starts with a lead for the n-grams across the cut. Failing that the cut
+// comes after any separator, and a chunk without any is sent whole,
+// splitting a word longer than the chunk; only these cuts can lose n-grams.
+func (e *Engine) split(r io.Reader, chunks chan<- chunk) error {
+	var carry []byte
+	var next chunk
+	for {
+		buf := make([]byte, len(carry)+e.ChunkSize)
+		copy(buf, carry)
+		n, err := io.ReadFull(r, buf[len(carry):])
+		buf = buf[:len(carry)+n]
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			if len(buf) > 0 {
+				next.text = buf
+				chunks <- next
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The lead was cut from the chunk before; the cut must come after it.
+		rest := buf[next.lead:]
+		following := chunk{midParagraph: true, midSentence: true}
+		withLead := false
+		cut := lastParagraphEnd(rest)
+		if cut > 0 {
+			following = chunk{}
+		} else if cut = lastSentenceEnd(rest); cut > 0 {
+			following.midSentence = false
+		}
+		if cut == 0 {
+			cut = lastRune(rest, isSeparator)
+			withLead = cut > 0
+		}
+		if cut == 0 {
+			cut = lastRune(rest, func(r rune) bool { return !isWordRune(r) })
+		}
+		if cut == 0 {
+			cut = len(rest)
+		}
+		cut += next.lead
+		next.text = buf[:cut]
+
+		var lead []byte
+		if withLead && e.N > 1 {
+			lead, following.skip = e.lead(next.text)
+			following.lead = len(lead)
+		}
+		carry = append(append([]byte(nil), lead...), buf[cut:]...)
+		chunks <- next
+		next = following
+	}
+}
+
+// lead returns the end of text a chunk going on with its last sentence
+// needs to count the n-grams across the cut, with the number of words in
+// it: at least N-1 words of the last sentence, starting after a separator,
+// or all of text.
+func (e *Engine) lead(text []byte) ([]byte, int) {
+	for size := 16 * e.N; size < len(text); size *= 2 {
+		start := len(text) - size
+		i := bytes.IndexFunc(text[start:], isSeparator)
+		if i < 0 {
+			continue
+		}
+		_, width := utf8.DecodeRune(text[start+i:])
+		window := text[start+i+width:]
+		words, last := 0, 0
+		e.Tokenizer.Sentences(string(window), func(sentence []string) {
+			words += len(sentence)
+			last = len(sentence)
+		})
+		if last >= e.N-1 {
+			return window, words
+		}
+	}
+	words := 0
+	EachWord(e.Tokenizer, string(text), func(string) { words++ })
+	return text, words
+}
+
+// isCutSpace reports whether a chunk may always be cut after r. No-break
+// spaces are left out since they can join the digits of a number.
+func isCutSpace(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
+
+// isSeparator reports whether r separates words whatever the tokenizer
+// rules: whitespace, or a character such as "，" that no rule joins words
+// with.
+func isSeparator(r rune) bool {
+	return isCutSpace(r) || !isWordRune(r) && r != utf8.RuneError &&
+		!strings.ContainsRune(numberJoiners+apostropheJoiners+hyphenJoiners, r)
+}
+
+// lastRune returns the offset just after the last rune of b that matches,
+// or 0 when there is none. Bytes of a rune cut off at the end of b never
+// match.
+func lastRune(b []byte, match func(r rune) bool) int {
+	for i := len(b); i > 0; {
+		r, size := utf8.DecodeLastRune(b[:i])
+		if r != utf8.RuneError && match(r) {
+			return i
+		}
+		i -= size
+	}
+	return 0
+}
+
+// lastParagraphEnd returns the offset just after the last blank line of b,
+// or 0 when there is none.
+func lastParagraphEnd(b []byte) int {
+	for i := len(b) - 1; i > 0; i-- {
+		if b[i] != '\n' {
+			continue
+		}
+		j := i - 1
+		for j >= 0 && b[j] != '\n' && isCutSpace(rune(b[j])) {
+			j--
+		}
+		if j >= 0 && b[j] == '\n' {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// lastSentenceEnd returns the offset just after the last sentence
+// terminator of b, or 0 when there is none. As in the tokenizer, a full
+// stop only counts when it is followed by something other than a word
+// character, so "3.14" is not cut; "。" and the other terminators always
+// count, since CJK text runs straight on after them.
+func lastSentenceEnd(b []byte) int {
+	next := utf8.RuneError
+	for i := len(b); i > 0; {
+		r, size := utf8.DecodeLastRune(b[:i])
+		if isSentenceEnd(r) && (r != '.' || next != utf8.RuneError && !isWordRune(next)) {
+			return i
+		}
+		next = r
+		i -= size
+	}
+	return 0
+}
@@ -0,0 +1,101 @@
+package words
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// chunkSizes range from a few words per chunk to the whole text at once.
+// Every word of the samples fits in the smallest, so none is split.
+var chunkSizes = []int{16, 40, 64, 1000, DefaultChunkSize}
+
+// sampleText repeats a few words in an order that does not repeat, joined
+// by sep, with no sentence or paragraph ends unless the words have them.
+func sampleText(words []string, n int, sep string) string {
+	var b strings.Builder
+	x := 1
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		x = (x*1103515245 + 12345) % (1 << 31)
+		b.WriteString(words[x/7%len(words)])
+	}
+	return b.String()
+}
+
+func TestCountReaderChunkSizes(t *testing.T) {
+	cjk := Rules{CJK: CJKBigram}
+	tests := []struct {
+		name string
+		text string
+		t    Tokenizer
+	}{
+		{"unpunctuated", sampleText([]string{"alpha", "beta", "gamma", "delta", "don't", "e-mail"}, 8000, " "), DefaultTokenizer},
+		{"lines", sampleText([]string{"one", "two", "three", "four"}, 8000, "\n"), DefaultTokenizer},
+		{"commas", sampleText([]string{"1,000", "x", "y", "z"}, 8000, ", "), DefaultTokenizer},
+		{"chinese", sampleText([]string{"北京大学", "我们", "学生"}, 3000, "，"), cjk},
+	}
+	for _, tt := range tests {
+		for n := 1; n <= 3; n++ {
+			var want Counts
+			for _, size := range chunkSizes {
+				e := NewEngine(4, size)
+				e.N, e.Tokenizer = n, tt.t
+				got, err := e.CountReader(strings.NewReader(tt.text))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want == nil {
+					want = make(Counts)
+					EachNGram(tt.t, tt.text, n, func(gram string) { want[gram]++ })
+				}
+				if got.Total() != want.Total() || !reflect.DeepEqual(got, want) {
+					t.Errorf("%s, %d-grams in chunks of %d: total %d, want %d", tt.name, n, size, got.Total(), want.Total())
+				}
+			}
+		}
+	}
+}
+
+func TestCountFilesWithStatsChunkSizes(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(&b, "%s. ", sampleText([]string{"the", "cat", "sat", "on", "mat"}, i%13+1, " "))
+		if i%17 == 0 {
+			b.WriteString("\n\n")
+		}
+	}
+	b.WriteString(sampleText([]string{"no", "end", "here"}, 500, " "))
+	path := filepath.Join(t.TempDir(), "sample.txt")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for n := 1; n <= 3; n++ {
+		var wantCounts Counts
+		var wantStats Stats
+		for i, size := range chunkSizes {
+			e := NewEngine(4, size)
+			e.N = n
+			counts, stats, err := e.CountFilesWithStats([]string{path})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if i == 0 {
+				wantCounts, wantStats = counts, stats
+				continue
+			}
+			if !reflect.DeepEqual(counts, wantCounts) {
+				t.Errorf("%d-grams in chunks of %d: total %d, want %d", n, size, counts.Total(), wantCounts.Total())
+			}
+			if stats != wantStats {
+				t.Errorf("%d-grams in chunks of %d: stats %+v, want %+v", n, size, stats, wantStats)
+			}
+		}
+	}
+}
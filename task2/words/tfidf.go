@@ -0,0 +1,75 @@
+package words
+
+import (
+	"math"
+	"sort"
+)
+
+// Document is one counted file of a collection.
+type Document struct {
+	Name   string
+	Counts Counts
+}
+
+// Collection is a set of documents with the number of documents each term
+// appears in.
+type Collection struct {
+	Documents []Document
+	DocFreq   Counts
+}
+
+// TermScore is how distinctive a term is for one document.
+type TermScore struct {
+	Term  string
+	Count int     // occurrences in the document
+	TF    float64 // share of the document's words
+	DF    int     // documents containing the term
+	TFIDF float64
+}
+
+// NewCollection computes the document frequencies of docs.
+func NewCollection(docs []Document) *Collection {
+	c := &Collection{Documents: docs, DocFreq: make(Counts)}
+	for _, doc := range docs {
+		for term := range doc.Counts {
+			c.DocFreq[term]++
+		}
+	}
+	return c
+}
+
+// IDF is the smoothed inverse document frequency ln((1+N)/(1+df)) + 1, which
+// stays positive for terms found in every document and in collections of a
+// single document.
+func (c *Collection) IDF(term string) float64 {
+	n := float64(len(c.Documents))
+	return math.Log((1+n)/(1+float64(c.DocFreq[term]))) + 1
+}
+
+// Scores ranks the terms of document i by TF-IDF, the most distinctive
+// first. Terms seen fewer than minCount times in the document are left out.
+func (c *Collection) Scores(i, minCount int) []TermScore {
+	doc := c.Documents[i].Counts
+	total := float64(doc.Total())
+	scores := make([]TermScore, 0, len(doc))
+	for term, n := range doc {
+		if n < minCount {
+			continue
+		}
+		tf := float64(n) / total
+		scores = append(scores, TermScore{
+			Term:  term,
+			Count: n,
+			TF:    tf,
+			DF:    c.DocFreq[term],
+			TFIDF: tf * c.IDF(term),
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].TFIDF != scores[j].TFIDF {
+			return scores[i].TFIDF > scores[j].TFIDF
+		}
+		return scores[i].Term < scores[j].Term
+	})
+	return scores
+}
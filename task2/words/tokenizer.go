@@ -0,0 +1,219 @@
+package words
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Tokenizer splits text into sentences of normalized words.
+type Tokenizer interface {
+	Tokens(text string, fn func(tok Token))
+	Sentences(text string, fn func(words []string))
+}
+
+// Token is one word of a text and where it was found.
+type Token struct {
+	Word      string // the word as counted: normalized and lower-cased
+	Text      string // the word as written, after Unicode normalization
+	Offset    int    // byte offset of Text in the normalized text
+	Line      int    // line of the text, from 1
+	Sentence  int    // sentence of the text, from 0
+	Paragraph int    // paragraph of the text, from 0
+}
+
+// Normalization is the Unicode normalization form applied before splitting.
+type Normalization string
+
+const (
+	// NormNone leaves the text as it is.
+	NormNone Normalization = "none"
+	// NormNFC composes characters, so "e" followed by a combining acute
+	// accent and the precomposed "é" are the same word.
+	NormNFC Normalization = "nfc"
+	// NormNFKC also folds compatibility characters such as ligatures and
+	// full-width letters, so "ﬁle" and "file" are the same word.
+	NormNFKC Normalization = "nfkc"
+)
+
+// ParseNormalization validates a normalization form name.
+func ParseNormalization(name string) (Normalization, error) {
+	switch Normalization(strings.ToLower(name)) {
+	case NormNone, NormNFC, NormNFKC:
+		return Normalization(strings.ToLower(name)), nil
+	default:
+		return "", fmt.Errorf("unknown normalization %q, use none, nfc or nfkc", name)
+	}
+}
+
+// Rules is the configurable Unicode-aware tokenizer. A word is a run of
+// letters, numbers and combining marks; each rule lets one more kind of
+// character join two words together. Unless CJK is CJKNone, a change
+// between CJK and other scripts also ends a word and CJK runs are segmented
+// by the CJK mode. The zero value splits on everything else and
+// lower-cases, as the counter always did.
+type Rules struct {
+	Apostrophes bool // "don't", "l’homme" stay one word
+	Hyphens     bool // "e-mail", "state-of-the-art" stay one word
+	Numbers     bool // "1,000.50", "3.14" stay one number
+	Form        Normalization
+	Fold        bool // full case folding, so "STRASSE" and "straße" match
+	CJK         CJKMode
+	Dict        *Dictionary // word list for CJKDict
+}
+
+// DefaultTokenizer splits words the way the counter always has.
+var DefaultTokenizer Tokenizer = Rules{}
+
+// isWordRune reports whether r belongs to a word. Everything else separates
+// words.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+// isSentenceEnd reports whether r ends a sentence.
+func isSentenceEnd(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…', '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+// The characters each rule lets join two words.
+const (
+	numberJoiners     = ",.'_\u00a0\u202f"
+	apostropheJoiners = "'\u2019\u02bc"
+	hyphenJoiners     = "-\u2010\u2011"
+)
+
+// join returns the character that r becomes inside a word when it sits
+// between prev and next, or false when it separates them.
+func (t Rules) join(prev, r, next rune) (rune, bool) {
+	switch {
+	case t.Numbers && unicode.IsDigit(prev) && unicode.IsDigit(next) && strings.ContainsRune(numberJoiners, r):
+		return r, true
+	case t.Apostrophes && unicode.IsLetter(prev) && unicode.IsLetter(next) && strings.ContainsRune(apostropheJoiners, r):
+		return '\'', true
+	case t.Hyphens && isWordRune(prev) && isWordRune(next) && strings.ContainsRune(hyphenJoiners, r):
+		return '-', true
+	}
+	return 0, false
+}
+
+// Tokens calls fn with every word of text in order. A full stop between
+// two word characters, as in "3.14", does not end a sentence; the last
+// sentence needs no terminator. Paragraphs are separated by blank lines,
+// which also end a sentence, so a heading without a full stop does not run
+// into the text below it.
+func (t Rules) Tokens(text string, fn func(tok Token)) {
+	switch t.Form {
+	case NormNFC:
+		text = norm.NFC.String(text)
+	case NormNFKC:
+		text = norm.NFKC.String(text)
+	}
+	lower := strings.ToLower
+	if t.Fold {
+		// A Caser keeps state, so each call gets its own.
+		lower = cases.Fold().String
+	}
+
+	segmentCJK := t.CJK != "" && t.CJK != CJKNone
+
+	line, sentence, inSentence := 1, 0, false
+	paragraph, inParagraph := 0, false
+	newlines := 0 // line breaks since the last character other than a space
+	start := -1
+	currentWord := strings.Builder{}
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		word, written, at := currentWord.String(), text[start:end], start
+		currentWord.Reset()
+		start = -1
+		inSentence, inParagraph = true, true
+		if first, _ := utf8.DecodeRuneInString(word); segmentCJK && isCJK(first) {
+			segment(written, t.CJK, t.Dict, func(part string, offset int) {
+				fn(Token{Word: part, Text: part, Offset: at + offset, Line: line, Sentence: sentence, Paragraph: paragraph})
+			})
+			return
+		}
+		fn(Token{Word: lower(word), Text: written, Offset: at, Line: line, Sentence: sentence, Paragraph: paragraph})
+	}
+
+	var prev rune
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		at := i
+		i += size
+		if isWordRune(r) {
+			if segmentCJK && !unicode.IsMark(r) && isCJK(r) != isCJK(prev) {
+				flush(at)
+			}
+			if start < 0 {
+				start = at
+			}
+			currentWord.WriteRune(r)
+			prev = r
+			newlines = 0
+			continue
+		}
+
+		next, _ := utf8.DecodeRuneInString(text[i:])
+		if joined, ok := t.join(prev, r, next); ok {
+			currentWord.WriteRune(joined)
+			prev = joined
+			continue
+		}
+		inWord := isWordRune(prev)
+		flush(at)
+		prev = r
+		switch {
+		case r == '\n':
+			line++
+			newlines++
+		case !unicode.IsSpace(r):
+			newlines = 0
+		}
+		if newlines >= 2 {
+			if inParagraph {
+				paragraph++
+				inParagraph = false
+			}
+			if inSentence {
+				sentence++
+				inSentence = false
+			}
+			continue
+		}
+		if !isSentenceEnd(r) || !inSentence || r == '.' && inWord && isWordRune(next) {
+			continue
+		}
+		sentence++
+		inSentence = false
+	}
+	flush(len(text))
+}
+
+// Sentences calls fn with the words of every sentence of text.
+func (t Rules) Sentences(text string, fn func(words []string)) {
+	var words []string
+	current := 0
+	t.Tokens(text, func(tok Token) {
+		if tok.Sentence != current && len(words) > 0 {
+			fn(words)
+			words = nil
+		}
+		current = tok.Sentence
+		words = append(words, tok.Word)
+	})
+	if len(words) > 0 {
+		fn(words)
+	}
+}